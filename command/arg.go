@@ -6,17 +6,31 @@ type Arg interface {
 	Expected() ValueType
 	IsOptional() bool
 	IsVariadic() bool
+	CompletionHint() CompletionHint
+	Choices() []string
+	Constraints() []string
+	completer() completer
+	parse(ctx *Context, value string) (interface{}, error)
+	expand(ctx *Context, value string) ([]interface{}, error)
 	validate(value interface{}) error
+	validateVariadic(values []interface{}) error
 	toArg() arg
 }
 
 type arg struct {
-	label       string
-	description string
-	expected    ValueType
-	validators  []validator
-	optional    bool
-	variadic    bool
+	label              string
+	description        string
+	expected           ValueType
+	validators         []validator
+	variadicValidators []validator
+	optional           bool
+	variadic           bool
+	parser             valueParser
+	expander           valueExpander
+	completion         CompletionHint
+	choices            []string
+	constraints        []string
+	completeFunc       completer
 }
 
 func (a arg) Label() string {
@@ -39,6 +53,22 @@ func (a arg) IsVariadic() bool {
 	return a.variadic
 }
 
+func (a arg) CompletionHint() CompletionHint {
+	return a.completion
+}
+
+func (a arg) Choices() []string {
+	return a.choices
+}
+
+func (a arg) Constraints() []string {
+	return a.constraints
+}
+
+func (a arg) completer() completer {
+	return a.completeFunc
+}
+
 func (a arg) toArg() arg {
 	return a
 }
@@ -78,6 +108,18 @@ func (a typedArg[T]) ExtendValidators(validators ...func(T) error) typedArg[T] {
 	return a
 }
 
+func (a typedArg[T]) ExtendVariadicValidators(validators ...func([]T) error) typedArg[T] {
+	for _, v := range validators {
+		a.arg.variadicValidators = append(a.arg.variadicValidators, toVariadicValidator(v))
+	}
+	return a
+}
+
+func (a typedArg[T]) Completer(completer completer) typedArg[T] {
+	a.arg.completeFunc = completer
+	return a
+}
+
 func (a typedArg[T]) AsOptional() typedArg[T] {
 	a.arg.optional = true
 	return a
@@ -116,6 +158,28 @@ func NewBoolArg(label string, description string) typedArg[bool] {
 	}
 }
 
+func (a arg) parse(ctx *Context, value string) (interface{}, error) {
+	if a.parser != nil {
+		return a.parser(ctx, value)
+	}
+	return parseValue(value, a.expected)
+}
+
+func (a arg) expand(ctx *Context, value string) ([]interface{}, error) {
+	if a.expander != nil {
+		return a.expander(ctx, value)
+	}
+	parsedValue, err := a.parse(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{parsedValue}, nil
+}
+
 func (a arg) validate(value interface{}) error {
 	return runValidators(a.validators, value)
 }
+
+func (a arg) validateVariadic(values []interface{}) error {
+	return runValidators(a.variadicValidators, values)
+}
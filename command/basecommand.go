@@ -1,8 +1,21 @@
 package command
 
+import "text/template"
+
 type baseCommand struct {
 	label       string
 	description string
+
+	parent      Command
+	examples    []Example
+	builtin     bool
+	hidden      bool
+	deprecation *deprecation
+
+	helpTemplate  *template.Template
+	usageTemplate *template.Template
+
+	duplicateChildren []string
 }
 
 func (c *baseCommand) Label() string {
@@ -13,9 +26,29 @@ func (c *baseCommand) Description() string {
 	return c.description
 }
 
+func (c *baseCommand) Parent() Command {
+	return c.parent
+}
+
+func (c *baseCommand) IsHidden() bool {
+	return c.hidden
+}
+
+func (c *baseCommand) base() *baseCommand {
+	return c
+}
+
 func newBaseCommand(label, description string) *baseCommand {
 	return &baseCommand{
 		label:       label,
 		description: description,
 	}
 }
+
+func (c *baseCommand) setHelpTemplate(text string) {
+	c.helpTemplate = template.Must(newHelpTemplate("help", text))
+}
+
+func (c *baseCommand) setUsageTemplate(text string) {
+	c.usageTemplate = template.Must(newHelpTemplate("usage", text))
+}
@@ -3,7 +3,17 @@ package command
 type Command interface {
 	Label() string
 	Description() string
-	run(args []string) error
+	Parent() Command
+	run(ctx *Context, args []string, inherited []Flag) error
+	complete(ctx *Context, args []string, inherited []Flag) ([]Completion, Directive)
 	PrintHelp()
-	inheritGlobalFlags(flags []Flag)
+	base() *baseCommand
+}
+
+func addChild(parent Command, children map[string]Command, child Command) {
+	if existing, ok := children[child.Label()]; ok && !existing.base().builtin {
+		parent.base().duplicateChildren = append(parent.base().duplicateChildren, child.Label())
+	}
+	child.base().parent = parent
+	children[child.Label()] = child
 }
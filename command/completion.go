@@ -0,0 +1,378 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const completeCommandName = "__complete"
+
+type Directive int
+
+const (
+	DirectiveDefault    Directive = 0
+	DirectiveNoSpace    Directive = 1 << 0
+	DirectiveNoFileComp Directive = 1 << 1
+	DirectiveFilterDirs Directive = 1 << 2
+)
+
+type Completion struct {
+	Value       string
+	Description string
+}
+
+func (c *RootCommand) EnableCompletion() *RootCommand {
+	bash := NewExecutableCommand("bash", "Generate the bash completion script").
+		Handler(func(ctx *Context, args ValidatedArgs) error {
+			return c.GenBashCompletion(ctx.Stdout())
+		})
+
+	zsh := NewExecutableCommand("zsh", "Generate the zsh completion script").
+		Handler(func(ctx *Context, args ValidatedArgs) error {
+			return c.GenZshCompletion(ctx.Stdout())
+		})
+
+	fish := NewExecutableCommand("fish", "Generate the fish completion script").
+		Handler(func(ctx *Context, args ValidatedArgs) error {
+			return c.GenFishCompletion(ctx.Stdout())
+		})
+
+	powershell := NewExecutableCommand("powershell", "Generate the PowerShell completion script").
+		Handler(func(ctx *Context, args ValidatedArgs) error {
+			return c.GenPowerShellCompletion(ctx.Stdout())
+		})
+
+	completion := NewSubcommand("completion", "Generate shell completion scripts").
+		AddChild(bash).
+		AddChild(zsh).
+		AddChild(fish).
+		AddChild(powershell)
+
+	return c.AddChild(completion)
+}
+
+func (c *RootCommand) runComplete(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	ctx.stdin = strings.NewReader("")
+	completions, directive := c.complete(ctx, args, nil)
+	for _, completion := range completions {
+		if completion.Description != "" {
+			fmt.Fprintf(ctx.Stdout(), "%s\t%s\n", completion.Value, completion.Description)
+			continue
+		}
+		fmt.Fprintln(ctx.Stdout(), completion.Value)
+	}
+	fmt.Fprintf(ctx.Stdout(), ":%d\n", directive)
+	return nil
+}
+
+func completeChildren(ctx *Context, children map[string]Command, args []string, flags []Flag) ([]Completion, Directive) {
+	for len(args) > 1 && strings.HasPrefix(args[0], "-") && len(args[0]) > 1 && args[0] != "--" {
+		f, hasValue := lookupFlagToken(flags, args[0])
+		if f != nil && !hasValue && f.Expected() != ValueTypeBool {
+			if len(args) == 2 {
+				return completeValue(ctx, f, args[1], "", *newValidatedArgs())
+			}
+			args = args[1:]
+		}
+		args = args[1:]
+	}
+
+	if len(args) == 1 {
+		partial := args[0]
+		if strings.HasPrefix(partial, "-") {
+			return completeFlagNames(flags, partial), DirectiveNoFileComp
+		}
+		completions := []Completion{}
+		for _, label := range sortedChildLabels(children) {
+			if isHidden(children[label]) {
+				continue
+			}
+			if strings.HasPrefix(label, partial) {
+				completions = append(completions, Completion{Value: label, Description: children[label].Description()})
+			}
+		}
+		return completions, DirectiveNoFileComp
+	}
+
+	child, ok := children[args[0]]
+	if !ok {
+		return nil, DirectiveNoFileComp
+	}
+	return child.complete(ctx, args[1:], flags)
+}
+
+func lookupFlagToken(flags []Flag, token string) (Flag, bool) {
+	if strings.HasPrefix(token, "--") {
+		name, _, hasValue := splitFlagNameValue(token[2:])
+		return lookupFlag(flags, func(f Flag) bool { return flagHasName(f, name) }), hasValue
+	}
+	shorthand, _, hasValue := splitFlagNameValue(token[1:])
+	return lookupFlag(flags, func(f Flag) bool { return f.Shorthand() != "" && f.Shorthand() == shorthand }), hasValue
+}
+
+type hiddenCommand interface {
+	IsHidden() bool
+}
+
+func isHidden(command Command) bool {
+	hidden, ok := command.(hiddenCommand)
+	return ok && hidden.IsHidden()
+}
+
+func sortedChildLabels(children map[string]Command) []string {
+	labels := make([]string, 0, len(children))
+	for label := range children {
+		labels = append(labels, label)
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+func completeFlagNames(flags []Flag, partial string) []Completion {
+	flags = visibleFlags(flags)
+	completions := []Completion{}
+	for _, f := range flags {
+		name := "--" + f.Name()
+		if strings.HasPrefix(name, partial) {
+			completions = append(completions, Completion{Value: name, Description: f.Description()})
+		}
+	}
+	if !strings.HasPrefix(partial, "--") {
+		for _, f := range flags {
+			if f.Shorthand() == "" {
+				continue
+			}
+			shorthand := "-" + f.Shorthand()
+			if strings.HasPrefix(shorthand, partial) {
+				completions = append(completions, Completion{Value: shorthand, Description: f.Description()})
+			}
+		}
+	}
+	return completions
+}
+
+type completable interface {
+	Expected() ValueType
+	Choices() []string
+	CompletionHint() CompletionHint
+	completer() completer
+}
+
+// Completion must not read stdin or touch the filesystem, so values whose
+// parsers do either are handed to completers as the raw strings typed so far.
+func keepRawForCompletion(expected ValueType) bool {
+	switch expected {
+	case ValueTypeInput, ValueTypeJSON, ValueTypePath:
+		return true
+	}
+	return false
+}
+
+func completeValue(ctx *Context, target completable, partial string, prefix string, args ValidatedArgs) ([]Completion, Directive) {
+	if custom := target.completer(); custom != nil {
+		completions, directive := custom(ctx, partial, args)
+		for i := range completions {
+			completions[i].Value = prefix + completions[i].Value
+		}
+		return completions, directive
+	}
+
+	choices := target.Choices()
+	if target.Expected() == ValueTypeBool {
+		choices = []string{"true", "false"}
+	}
+	if len(choices) > 0 {
+		completions := []Completion{}
+		for _, choice := range choices {
+			if strings.HasPrefix(choice, partial) {
+				completions = append(completions, Completion{Value: prefix + choice})
+			}
+		}
+		return completions, DirectiveNoFileComp
+	}
+
+	switch target.CompletionHint() {
+	case CompletionHintFile:
+		return nil, DirectiveDefault
+	case CompletionHintDir:
+		return nil, DirectiveFilterDirs
+	default:
+		return nil, DirectiveNoFileComp
+	}
+}
+
+func mergeFlags(local []Flag, inherited []Flag) []Flag {
+	flags := []Flag{}
+	seen := make(map[string]bool)
+	for _, f := range append(slices.Clone(local), inherited...) {
+		if seen[f.Name()] {
+			continue
+		}
+		seen[f.Name()] = true
+		flags = append(flags, f)
+	}
+	return flags
+}
+
+func (c *executableCommand) complete(ctx *Context, args []string, inherited []Flag) ([]Completion, Directive) {
+	if child, index := findChild(c.children, args[:len(args)-1], mergeFlags(c.flags, inherited)); child != nil {
+		return child.complete(ctx, args[index+1:], inherited)
+	}
+
+	ctx.command = c
+	flags := mergeFlags(c.flags, inherited)
+	maps := buildFlagMaps(flags)
+
+	lookup := func(token string) (Flag, string, bool, bool) {
+		var name, value string
+		var hasValue bool
+		var f Flag
+		var ok bool
+		switch {
+		case len(token) > 2 && token[0:2] == "--":
+			name, value, hasValue = splitFlagNameValue(token[2:])
+			f, ok = maps.byName[name]
+		case len(token) > 1 && token[0] == '-':
+			name, value, hasValue = splitFlagNameValue(token[1:])
+			f, ok = maps.byShorthand[name]
+		}
+		return f, value, hasValue, ok
+	}
+
+	validatedArgs := newValidatedArgs()
+	for _, f := range flags {
+		if f.DefaultValue() != nil {
+			validatedArgs.setFlag(f.Name(), f.DefaultValue())
+		}
+	}
+
+	used := make(map[string]bool)
+	positionalArgs := []string{}
+	var pendingFlag Flag
+	onlyPositional := false
+	last := len(args) - 1
+	for i := 0; i < last; i++ {
+		token := args[i]
+		if onlyPositional {
+			positionalArgs = append(positionalArgs, token)
+			continue
+		}
+		if token == "--" {
+			onlyPositional = true
+			continue
+		}
+		if strings.HasPrefix(token, "-") && len(token) > 1 {
+			f, value, hasValue, ok := lookup(token)
+			if !ok {
+				continue
+			}
+			used[f.Name()] = true
+			if !hasValue && f.Expected() != ValueTypeBool {
+				if i+1 == last {
+					pendingFlag = f
+					break
+				}
+				i++
+				value = args[i]
+			}
+			if keepRawForCompletion(f.Expected()) {
+				validatedArgs.setFlag(f.Name(), value)
+			} else if parsedValue, err := f.parse(ctx, value); err == nil {
+				validatedArgs.setFlag(f.Name(), parsedValue)
+			}
+			continue
+		}
+		positionalArgs = append(positionalArgs, token)
+	}
+
+	for i, a := range c.args {
+		if a.IsVariadic() {
+			values := []interface{}{}
+			for _, raw := range positionalArgs[min(i, len(positionalArgs)):] {
+				if keepRawForCompletion(a.Expected()) {
+					values = append(values, raw)
+				} else if parsedValues, err := a.expand(ctx, raw); err == nil {
+					values = append(values, parsedValues...)
+				}
+			}
+			validatedArgs.setVariadic(a.Label(), values)
+			break
+		}
+		if i >= len(positionalArgs) {
+			break
+		}
+		if keepRawForCompletion(a.Expected()) {
+			validatedArgs.set(a.Label(), positionalArgs[i])
+		} else if parsedValue, err := a.parse(ctx, positionalArgs[i]); err == nil {
+			validatedArgs.set(a.Label(), parsedValue)
+		}
+	}
+
+	partial := args[last]
+	if pendingFlag != nil {
+		return completeValue(ctx, pendingFlag, partial, "", *validatedArgs)
+	}
+
+	if !onlyPositional && strings.HasPrefix(partial, "-") {
+		if f, value, hasValue, ok := lookup(partial); ok && hasValue {
+			prefix := strings.TrimSuffix(partial, value)
+			return completeValue(ctx, f, value, prefix, *validatedArgs)
+		}
+		unused := []Flag{}
+		for _, f := range flags {
+			if !used[f.Name()] {
+				unused = append(unused, f)
+			}
+		}
+		return completeFlagNames(unused, partial), DirectiveNoFileComp
+	}
+
+	children := []Completion{}
+	if len(positionalArgs) == 0 && !onlyPositional {
+		children, _ = completeChildren(ctx, c.children, []string{partial}, nil)
+	}
+
+	if len(c.args) == 0 {
+		return children, DirectiveNoFileComp
+	}
+	index := len(positionalArgs)
+	if index >= len(c.args) {
+		if !c.args[len(c.args)-1].IsVariadic() {
+			return nil, DirectiveNoFileComp
+		}
+		index = len(c.args) - 1
+	}
+	completions, directive := completeValue(ctx, c.args[index], partial, "", *validatedArgs)
+	return append(children, completions...), directive
+}
+
+func (c *RootCommand) complete(ctx *Context, args []string, inherited []Flag) ([]Completion, Directive) {
+	flags := mergeFlags(c.globalFlags, inherited)
+	if len(args) == 1 && strings.HasPrefix(args[0], "-") {
+		return completeFlagNames(mergeFlags(flags, c.versionFlags()), args[0]), DirectiveNoFileComp
+	}
+	return completeChildren(ctx, c.children, args, flags)
+}
+
+func (c *Subcommand) complete(ctx *Context, args []string, inherited []Flag) ([]Completion, Directive) {
+	if defaultCommand := c.defaultCommand(); defaultCommand != nil {
+		partial := args[len(args)-1]
+		if len(args) == 1 && strings.HasPrefix(partial, "-") || len(args) > 1 && c.runsDefault(args[:len(args)-1], inherited) {
+			return defaultCommand.complete(ctx, args, inherited)
+		}
+	}
+	return completeChildren(ctx, c.children, args, inherited)
+}
+
+func shellIdentifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"fmt"
+	"io"
+)
+
+func (c *RootCommand) GenBashCompletion(w io.Writer) error {
+	name := c.Label()
+	function := "__" + shellIdentifier(name) + "_complete"
+	_, err := fmt.Fprintf(w, bashCompletionTemplate, name, function, completeCommandName,
+		DirectiveNoSpace, DirectiveNoFileComp, DirectiveFilterDirs, name)
+	return err
+}
+
+const bashCompletionTemplate = `# bash completion for %[1]s
+
+%[2]s() {
+    local cur words cword
+    if declare -F _get_comp_words_by_ref >/dev/null 2>&1; then
+        _get_comp_words_by_ref -n "=:" cur words cword
+    else
+        cur="${COMP_WORDS[COMP_CWORD]}"
+        words=("${COMP_WORDS[@]}")
+        cword=$COMP_CWORD
+    fi
+
+    local out
+    out=$("${words[0]}" %[3]s "${words[@]:1:$cword}" 2>/dev/null)
+    if [[ $? -ne 0 ]]; then
+        return
+    fi
+
+    local directive=${out##*:}
+    if [[ $out == *$'\n'* ]]; then
+        out=${out%%$'\n'*}
+    else
+        out=""
+    fi
+    if [[ ! $directive =~ ^[0-9]+$ ]]; then
+        directive=0
+    fi
+
+    if (( directive & %[4]d )); then
+        compopt -o nospace 2>/dev/null
+    fi
+
+    if (( directive & %[6]d )); then
+        compopt -o dirnames 2>/dev/null
+        COMPREPLY=($(compgen -d -- "$cur"))
+        return
+    fi
+
+    local candidates=()
+    local line
+    while IFS='' read -r line; do
+        [[ -n $line ]] && candidates+=("${line%%%%$'\t'*}")
+    done <<< "$out"
+
+    COMPREPLY=($(compgen -W "${candidates[*]}" -- "$cur"))
+    if [[ ${#COMPREPLY[@]} -eq 0 ]] && (( directive & %[5]d )); then
+        compopt +o default 2>/dev/null
+    fi
+}
+
+complete -o default -F %[2]s %[7]s
+`
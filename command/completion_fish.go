@@ -0,0 +1,49 @@
+package command
+
+import (
+	"fmt"
+	"io"
+)
+
+func (c *RootCommand) GenFishCompletion(w io.Writer) error {
+	name := c.Label()
+	function := "__" + shellIdentifier(name) + "_complete"
+	_, err := fmt.Fprintf(w, fishCompletionTemplate, name, function, completeCommandName,
+		DirectiveNoFileComp, DirectiveFilterDirs, DirectiveNoSpace)
+	return err
+}
+
+const fishCompletionTemplate = `# fish completion for %[1]s
+
+function %[2]s
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    set -l out ($tokens[1] %[3]s $tokens[2..-1] "$current" 2>/dev/null)
+    or return
+
+    set -l directive (string replace -r '^:' '' -- $out[-1])
+    set -e out[-1]
+
+    if test (math "bitand($directive, %[5]d)") -ne 0
+        __fish_complete_directories "$current"
+        return
+    end
+
+    if test (count $out) -eq 0
+        if test (math "bitand($directive, %[4]d)") -eq 0
+            __fish_complete_path "$current"
+        end
+        return
+    end
+
+    printf '%%s\n' $out
+    if test (count $out) -eq 1; and test (math "bitand($directive, %[6]d)") -ne 0
+        # fish adds a space after a single match, so offer a second one
+        # sharing its prefix to have only the common prefix inserted.
+        printf '%%s.\n' (string split -m 1 \t -- $out[1])[1]
+    end
+end
+
+complete -c %[1]s -e
+complete -c %[1]s -f -a '(%[2]s)'
+`
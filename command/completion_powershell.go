@@ -0,0 +1,85 @@
+package command
+
+import (
+	"fmt"
+	"io"
+)
+
+func (c *RootCommand) GenPowerShellCompletion(w io.Writer) error {
+	name := c.Label()
+	_, err := fmt.Fprintf(w, powerShellCompletionTemplate, name, completeCommandName,
+		DirectiveNoFileComp, DirectiveFilterDirs)
+	return err
+}
+
+const powerShellCompletionTemplate = `# powershell completion for %[1]s
+
+Register-ArgumentCompleter -Native -CommandName '%[1]s' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $elements = $commandAst.CommandElements | Where-Object { $_.Extent.StartOffset -lt $cursorPosition }
+    $program = $elements[0].Extent.Text
+    $words = @()
+    foreach ($element in ($elements | Select-Object -Skip 1)) {
+        if ($element -is [System.Management.Automation.Language.StringConstantExpressionAst]) {
+            $words += $element.Value
+        } else {
+            $words += $element.Extent.Text
+        }
+    }
+    if ($wordToComplete -eq '') {
+        $words += ''
+    }
+    if ($PSVersionTable.PSVersion -lt [version]'7.3' -and $words[-1] -eq '') {
+        $words[-1] = '""'
+    }
+
+    $out = @(& $program %[2]s @words 2>$null)
+    if ($out.Count -eq 0) {
+        return
+    }
+
+    $directive = 0
+    $last = $out[-1]
+    if ($last -match '^:(\d+)$') {
+        $directive = [int]$Matches[1]
+        $out = @($out | Select-Object -SkipLast 1)
+    }
+
+    if (($directive -band %[4]d) -ne 0) {
+        $prefix = $wordToComplete -replace '[^/\\]*$', ''
+        Get-ChildItem -Directory -Path "$wordToComplete*" -ErrorAction SilentlyContinue | ForEach-Object {
+            $path = $prefix + $_.Name
+            $completionText = $path
+            if ($path -match '\s') {
+                $completionText = "'" + $path.Replace("'", "''") + "'"
+            }
+            [System.Management.Automation.CompletionResult]::new($completionText, $_.Name, 'ProviderContainer', $_.FullName)
+        }
+        return
+    }
+
+    $results = @()
+    foreach ($line in $out) {
+        if ($line -eq '') {
+            continue
+        }
+        $parts = $line.Split([char]9, 2)
+        $value = $parts[0]
+        $tooltip = $value
+        if ($parts.Count -gt 1 -and $parts[1] -ne '') {
+            $tooltip = $parts[1]
+        }
+        $completionText = $value
+        if ($value -match '\s') {
+            $completionText = "'" + $value.Replace("'", "''") + "'"
+        }
+        $results += [System.Management.Automation.CompletionResult]::new($completionText, $value, 'ParameterValue', $tooltip)
+    }
+
+    if ($results.Count -eq 0 -and ($directive -band %[3]d) -ne 0) {
+        return ''
+    }
+    $results
+}
+`
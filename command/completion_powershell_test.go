@@ -0,0 +1,34 @@
+package command_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenPowerShellCompletion(t *testing.T) {
+	root := newTestRoot(&recorder{}).EnableCompletion()
+
+	var b bytes.Buffer
+	if err := root.GenPowerShellCompletion(&b); err != nil {
+		t.Fatalf("GenPowerShellCompletion returned error: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "completion.ps1.golden")
+	if *update {
+		if err := os.WriteFile(golden, b.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("GenPowerShellCompletion output differs from %s (run with -update to accept):\n%s", golden, b.String())
+	}
+}
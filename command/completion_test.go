@@ -0,0 +1,18 @@
+package command_test
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompleteWithoutEnableCompletion(t *testing.T) {
+	var out bytes.Buffer
+	root := newTestRoot(&recorder{}).Stdout(&out)
+
+	if err := root.Run([]string{"__complete", "de"}); err != nil {
+		t.Fatalf("__complete returned error: %v", err)
+	}
+	if want := "deploy\tDeploy a service\n:2\n"; out.String() != want {
+		t.Errorf("__complete printed %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,192 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func (c *RootCommand) GenZshCompletion(w io.Writer) error {
+	name := c.Label()
+	prefix := "_" + shellIdentifier(name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n# zsh completion for %s\n", name, name)
+	writeZshDispatcher(&b, prefix, prefix, c.children, c.globalFlags, c.versionFlags())
+	fmt.Fprintf(&b, zshDynamicTemplate, prefix, completeCommandName,
+		DirectiveNoSpace, DirectiveNoFileComp, DirectiveFilterDirs)
+	fmt.Fprintf(&b, "\nif [ \"$funcstack[1]\" = \"%[1]s\" ]; then\n    %[1]s \"$@\"\nelse\n    compdef %[1]s %[2]s\nfi\n", prefix, name)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeZshDispatcher(b *strings.Builder, function string, prefix string, children map[string]Command, flags []Flag, local []Flag) {
+	labels := []string{}
+	for _, label := range sortedChildLabels(children) {
+		if !isHidden(children[label]) {
+			labels = append(labels, label)
+		}
+	}
+
+	fmt.Fprintf(b, "\n%s() {\n", function)
+	b.WriteString("    local context state state_descr line\n")
+	b.WriteString("    typeset -A opt_args\n\n")
+	b.WriteString("    _arguments -C \\\n")
+	for _, f := range visibleFlags(mergeFlags(flags, local)) {
+		b.WriteString("        " + zshFlagSpec(f, prefix+"_dynamic") + " \\\n")
+	}
+	b.WriteString("        '1: :->command' \\\n")
+	b.WriteString("        '*:: :->args'\n\n")
+	b.WriteString("    case $state in\n")
+	b.WriteString("        command)\n")
+	b.WriteString("            local -a commands\n")
+	b.WriteString("            commands=(\n")
+	for _, label := range labels {
+		fmt.Fprintf(b, "                '%s:%s'\n", zshEscape(label), zshEscape(children[label].Description()))
+	}
+	b.WriteString("            )\n")
+	b.WriteString("            _describe -t commands 'command' commands\n")
+	b.WriteString("            ;;\n")
+	b.WriteString("        args)\n")
+	b.WriteString("            case $words[1] in\n")
+	for _, label := range labels {
+		fmt.Fprintf(b, "                %s) %s ;;\n", zshCaseLabel(label), function+"_"+shellIdentifier(label))
+	}
+	b.WriteString("            esac\n")
+	b.WriteString("            ;;\n")
+	b.WriteString("    esac\n")
+	b.WriteString("}\n")
+
+	for _, label := range labels {
+		childFunction := function + "_" + shellIdentifier(label)
+		switch child := children[label].(type) {
+		case *Subcommand:
+			if child.defaultChild != "" {
+				writeZshDelegate(b, childFunction, prefix)
+			} else {
+				writeZshDispatcher(b, childFunction, prefix, child.children, flags, nil)
+			}
+		case *executableCommand:
+			if len(child.children) > 0 {
+				writeZshDelegate(b, childFunction, prefix)
+			} else {
+				writeZshExecutable(b, childFunction, prefix, child, flags)
+			}
+		}
+	}
+}
+
+func writeZshExecutable(b *strings.Builder, function string, prefix string, cmd *executableCommand, inherited []Flag) {
+	dynamic := prefix + "_dynamic"
+
+	specs := []string{}
+	for _, f := range visibleFlags(mergeFlags(cmd.flags, inherited)) {
+		specs = append(specs, zshFlagSpec(f, dynamic))
+	}
+	specs = append(specs, "'(- *)'{-h,--help}'[Show help]'")
+
+	for i, a := range cmd.args {
+		message := zshEscape(a.Label())
+		switch {
+		case a.IsVariadic():
+			specs = append(specs, fmt.Sprintf("'*:%s:%s'", message, dynamic))
+		case a.IsOptional():
+			specs = append(specs, fmt.Sprintf("'%d::%s:%s'", i+1, message, dynamic))
+		default:
+			specs = append(specs, fmt.Sprintf("'%d:%s:%s'", i+1, message, dynamic))
+		}
+	}
+
+	fmt.Fprintf(b, "\n%s() {\n", function)
+	b.WriteString("    _arguments \\\n")
+	for i, spec := range specs {
+		b.WriteString("        " + spec)
+		if i < len(specs)-1 {
+			b.WriteString(" \\")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+}
+
+func writeZshDelegate(b *strings.Builder, function string, prefix string) {
+	fmt.Fprintf(b, "\n%s() {\n    %s_dynamic\n}\n", function, prefix)
+}
+
+func zshFlagSpec(f Flag, dynamic string) string {
+	names := "--" + f.Name()
+	exclusion := names
+	if f.Shorthand() != "" {
+		names = fmt.Sprintf("{-%s,--%s}", f.Shorthand(), f.Name())
+		exclusion = fmt.Sprintf("-%s --%s", f.Shorthand(), f.Name())
+	}
+
+	description := zshEscape(f.Description())
+	if f.Expected() == ValueTypeBool {
+		return fmt.Sprintf("'(%s)'%s'[%s]'", exclusion, names, description)
+	}
+	return fmt.Sprintf("'(%s)'%s'=[%s]:%s:%s'", exclusion, names, description, zshEscape(f.Name()), dynamic)
+}
+
+func zshEscape(s string) string {
+	return strings.NewReplacer(
+		"'", `'\''`,
+		"[", `\[`,
+		"]", `\]`,
+		":", `\:`,
+	).Replace(s)
+}
+
+func zshCaseLabel(label string) string {
+	return "'" + strings.ReplaceAll(label, "'", `'\''`) + "'"
+}
+
+const zshDynamicTemplate = `
+%[1]s_dynamic() {
+    local -a args completions suffix
+    local out directive line
+
+    args=("${(@Q)${(z)LBUFFER}}")
+    if [[ $LBUFFER == *[[:space:]] ]]; then
+        args+=('')
+    fi
+    if [[ ${args[-1]} == -*=* ]]; then
+        args=("${(@)args[1,-2]}" "${args[-1]%%%%=*}" "${args[-1]#*=}")
+    fi
+
+    out=$(${args[1]} %[2]s "${(@)args[2,-1]}" 2>/dev/null) || return 1
+    directive=${out##*:}
+    if [[ $out == *$'\n'* ]]; then
+        out=${out%%$'\n'*}
+    else
+        out=""
+    fi
+
+    if (( directive & %[5]d )); then
+        _files -/
+        return
+    fi
+
+    for line in ${(f)out}; do
+        if [[ $line == *$'\t'* ]]; then
+            completions+=("${${line%%%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+        else
+            completions+=("${line//:/\\:}")
+        fi
+    done
+
+    if (( ${#completions} == 0 )); then
+        if (( directive & %[4]d )); then
+            return 1
+        fi
+        _files
+        return
+    fi
+
+    if (( directive & %[3]d )); then
+        suffix=(-S '')
+    fi
+    _describe -t values 'value' completions "${suffix[@]}"
+}
+`
@@ -1,17 +1,41 @@
 package command
 
-import stdcontext "context"
+import (
+	stdcontext "context"
+	"io"
+	"os"
+	"path/filepath"
+)
 
 type Context struct {
 	context stdcontext.Context
 
-	command Command
+	command    Command
+	workingDir string
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+
+	aggregateErrors  bool
+	dryRun           bool
+	globalFlagValues map[string]interface{}
+	leadingErrors    ValidationErrors
 }
 
 func newContext(ctx stdcontext.Context, command Command) *Context {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		workingDir = "."
+	}
 	return &Context{
-		context: ctx,
-		command: command,
+		context:    ctx,
+		command:    command,
+		workingDir: workingDir,
+		stdin:      os.Stdin,
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+
+		globalFlagValues: make(map[string]interface{}),
 	}
 }
 
@@ -22,3 +46,32 @@ func (c *Context) Context() stdcontext.Context {
 func (c *Context) Command() Command {
 	return c.command
 }
+
+func (c *Context) WorkingDir() string {
+	return c.workingDir
+}
+
+func (c *Context) Stdin() io.Reader {
+	return c.stdin
+}
+
+func (c *Context) Stdout() io.Writer {
+	return c.stdout
+}
+
+func (c *Context) Stderr() io.Writer {
+	return c.stderr
+}
+
+func (c *Context) ResolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(c.workingDir, path)
+}
+
+func (c *Context) takeLeadingErrors() ValidationErrors {
+	errs := c.leadingErrors
+	c.leadingErrors = nil
+	return errs
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type deprecation struct {
+	message   string
+	failAfter string
+}
+
+func (d *deprecation) deprecate(message string) *deprecation {
+	next := deprecation{}
+	if d != nil {
+		next = *d
+	}
+	next.message = message
+	return &next
+}
+
+func (d *deprecation) failAfterVersion(version string) *deprecation {
+	next := deprecation{}
+	if d != nil {
+		next = *d
+	}
+	next.failAfter = version
+	return &next
+}
+
+func (d *deprecation) expired(ctx *Context) bool {
+	if d.failAfter == "" {
+		return false
+	}
+	root := rootOf(ctx.command)
+	if root == nil {
+		return false
+	}
+	cmp, ok := compareVersions(root.VersionInfo().Version, d.failAfter)
+	return ok && cmp > 0
+}
+
+func (d *deprecation) suffix() string {
+	if d.message == "" {
+		return ""
+	}
+	return ": " + d.message
+}
+
+func checkCommandDeprecation(ctx *Context, cmd Command) error {
+	d := cmd.base().deprecation
+	if d == nil {
+		return nil
+	}
+	ctx.command = cmd
+	if d.expired(ctx) {
+		return fmt.Errorf("command %q is no longer supported after version %s%s", cmd.Label(), d.failAfter, d.suffix())
+	}
+	fmt.Fprintf(ctx.Stderr(), "warning: command %q is deprecated%s\n", cmd.Label(), d.suffix())
+	return nil
+}
+
+func checkFlagDeprecation(ctx *Context, f Flag, name string, long bool) error {
+	if long && name != f.Name() {
+		fmt.Fprintf(ctx.Stderr(), "warning: flag --%s is deprecated: use --%s instead\n", name, f.Name())
+	}
+	d := f.deprecationInfo()
+	if d == nil {
+		return nil
+	}
+	if d.expired(ctx) {
+		return newFieldError(ErrorKindRemoved, f.Name(), nil, "flag --%s is no longer supported after version %s%s", f.Name(), d.failAfter, d.suffix())
+	}
+	fmt.Fprintf(ctx.Stderr(), "warning: flag --%s is deprecated%s\n", f.Name(), d.suffix())
+	return nil
+}
+
+func compareVersions(a string, b string) (int, bool) {
+	left, ok := parseVersion(a)
+	if !ok {
+		return 0, false
+	}
+	right, ok := parseVersion(b)
+	if !ok {
+		return 0, false
+	}
+	for i := range left {
+		if left[i] != right[i] {
+			if left[i] < right[i] {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i != -1 {
+		version = version[:i]
+	}
+	fields := strings.Split(version, ".")
+	if len(fields) > len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
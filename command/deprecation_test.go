@@ -0,0 +1,114 @@
+package command_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestDeprecatedCommandFailAfter(t *testing.T) {
+	tests := []struct {
+		version   string
+		failAfter string
+		expired   bool
+	}{
+		{"1.9.0", "2.0.0", false},
+		{"2.0.0", "2.0.0", false},
+		{"2.0.1", "2.0.0", true},
+		{"v2.1", "2.0.0", true},
+		{"2.1.0", "v2.1.0", false},
+		{"3.0.0-rc.1", "2.9.9", true},
+		{"2.0.0+build.5", "2.0.0", false},
+		{"10.0.0", "9.0.0", true},
+		{"devel", "1.0.0", false},
+		{"2.0.0", "not-a-version", false},
+	}
+	for _, tt := range tests {
+		var stderr bytes.Buffer
+		ran := false
+		legacy := command.NewExecutableCommand("legacy", "Old command").
+			Deprecated("use modern instead").
+			FailAfter(tt.failAfter).
+			Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+				ran = true
+				return nil
+			})
+		root := command.NewRootCommand("app", "Test application").
+			Version(tt.version).
+			Stderr(&stderr).
+			AddChild(legacy)
+
+		err := root.Run([]string{"legacy"})
+		if tt.expired {
+			if err == nil || !strings.Contains(err.Error(), "no longer supported after version "+tt.failAfter) || ran {
+				t.Errorf("version %s, fail after %s: got %v (ran=%t), want an expiry error", tt.version, tt.failAfter, err, ran)
+			}
+			continue
+		}
+		if err != nil || !ran {
+			t.Errorf("version %s, fail after %s: got %v (ran=%t), want the command to run", tt.version, tt.failAfter, err, ran)
+		}
+		if want := `warning: command "legacy" is deprecated: use modern instead`; !strings.Contains(stderr.String(), want) {
+			t.Errorf("version %s, fail after %s: stderr %q, want %q", tt.version, tt.failAfter, stderr.String(), want)
+		}
+	}
+}
+
+func TestDeprecatedFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	var region, output string
+	deploy := command.NewExecutableCommand("deploy", "Deploy").
+		Args(command.NewStringArg("target", "Deployment target").AsOptional()).
+		Flags(
+			command.NewStringFlag("region", "r", "Target region", "us-east-1").DeprecatedAlias("zone", "area"),
+			command.NewStringFlag("output", "o", "Output format", "text").Deprecated("output is always text"),
+			command.NewStringFlag("legacy-mode", "", "Legacy mode", "").FailAfter("1.0.0"),
+		).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			region = args.FlagString("region")
+			output = args.FlagString("output")
+			return nil
+		})
+	root := command.NewRootCommand("app", "Test application").
+		Version("2.0.0").
+		Stderr(&stderr).
+		AddChild(deploy)
+
+	if err := root.Run([]string{"deploy", "--zone", "eu-west-1"}); err != nil {
+		t.Fatalf("Run with an alias returned %v", err)
+	}
+	if region != "eu-west-1" {
+		t.Errorf("region = %q, want the alias value", region)
+	}
+	if want := "warning: flag --zone is deprecated: use --region instead"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr %q, want %q", stderr.String(), want)
+	}
+
+	stderr.Reset()
+	if err := root.Run([]string{"deploy", "--area=ap-south-1", "-o", "json"}); err != nil {
+		t.Fatalf("Run with a deprecated flag returned %v", err)
+	}
+	if region != "ap-south-1" || output != "json" {
+		t.Errorf("region = %q, output = %q", region, output)
+	}
+	if want := "warning: flag --output is deprecated: output is always text"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr %q, want %q", stderr.String(), want)
+	}
+
+	err := root.Run([]string{"deploy", "--legacy-mode", "on", "web"})
+	var fieldErr *command.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Kind != command.ErrorKindRemoved || fieldErr.Field != "legacy-mode" {
+		t.Fatalf("Run with a removed flag returned %v, want an ErrorKindRemoved error for legacy-mode", err)
+	}
+
+	stderr.Reset()
+	if err := root.Run([]string{"deploy"}); err != nil {
+		t.Fatalf("Run without deprecated flags returned %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("unused deprecated flags printed %q", stderr.String())
+	}
+}
@@ -0,0 +1,36 @@
+package command
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func enumValidator(choices []string) validator {
+	return func(value interface{}) error {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+		if !slices.Contains(choices, str) {
+			return fmt.Errorf("must be one of %s, got %s", strings.Join(choices, ", "), str)
+		}
+		return nil
+	}
+}
+
+func NewEnumArg(label string, description string, choices ...string) typedArg[string] {
+	a := typedArg[string]{
+		arg: NewArg(label, description, ValueTypeEnum, enumValidator(choices)),
+	}
+	a.arg.choices = choices
+	return a
+}
+
+func NewEnumFlag(name string, shorthand string, description string, defaultValue string, choices ...string) typedFlag[string] {
+	f := typedFlag[string]{
+		flag: NewFlag(name, shorthand, description, ValueTypeEnum, defaultValue, enumValidator(choices)),
+	}
+	f.flag.choices = choices
+	return f
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Example struct {
+	Title       string
+	CommandLine string
+}
+
+func (c *RootCommand) Example(title string, commandLine string) *RootCommand {
+	c.examples = append(c.examples, Example{Title: title, CommandLine: commandLine})
+	return c
+}
+
+func (c *Subcommand) Example(title string, commandLine string) *Subcommand {
+	c.examples = append(c.examples, Example{Title: title, CommandLine: commandLine})
+	return c
+}
+
+func (c *executableCommand) Example(title string, commandLine string) *executableCommand {
+	c.examples = append(c.examples, Example{Title: title, CommandLine: commandLine})
+	return c
+}
+
+func (c *RootCommand) CheckExamples() error {
+	var errs []error
+	if err := c.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	c.Info().Walk(func(info *CommandInfo) error {
+		for _, example := range info.Examples {
+			if err := c.checkExample(example); err != nil {
+				errs = append(errs, fmt.Errorf("example %q of %s: %w", example.Title, info.CommandPath(), err))
+			}
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
+
+func (c *RootCommand) checkExample(example Example) error {
+	words, err := splitCommandLine(example.CommandLine)
+	if err != nil {
+		return err
+	}
+	if len(words) == 0 || words[0] != c.Label() {
+		return fmt.Errorf("command line must start with %q", c.Label())
+	}
+
+	ctx := c.newRunContext(stdcontext.Background())
+	ctx.stdin = strings.NewReader("")
+	ctx.stdout = io.Discard
+	ctx.stderr = io.Discard
+	ctx.dryRun = true
+	if err := c.run(ctx, words[1:], nil); err != nil && !errors.Is(err, ErrHelp) {
+		return err
+	}
+	return nil
+}
+
+func splitCommandLine(line string) ([]string, error) {
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, line)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash in %q", line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,57 @@
+package command_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func newExampleRoot(r *recorder, commandLine string) *command.RootCommand {
+	deploy := command.NewExecutableCommand("deploy", "Deploy a service").
+		Args(command.NewStringArg("target", "Deployment target")).
+		Flags(command.NewBoolFlag("force", "f", "Skip confirmation", false)).
+		Example("Deploy without confirmation", commandLine).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			r.record("deploy %s", args.String("target"))
+			return nil
+		})
+	return command.NewRootCommand("app", "Test application").
+		Example("Show help", "app --help").
+		AddChild(deploy)
+}
+
+func TestCheckExamples(t *testing.T) {
+	r := &recorder{}
+	root := newExampleRoot(r, `app deploy "web frontend" --force`)
+
+	if err := root.CheckExamples(); err != nil {
+		t.Fatalf("CheckExamples returned error: %v", err)
+	}
+	if len(r.calls) != 0 {
+		t.Errorf("CheckExamples ran handlers: %q", r.calls)
+	}
+}
+
+func TestCheckExamplesReportsBrokenExamples(t *testing.T) {
+	tests := []struct {
+		commandLine string
+		want        string
+	}{
+		{"app deploy web --bogus", "unknown flag: --bogus"},
+		{"app deploy", "not enough arguments"},
+		{"other deploy web", `must start with "app"`},
+		{`app deploy "web`, "unterminated"},
+	}
+	for _, tt := range tests {
+		root := newExampleRoot(&recorder{}, tt.commandLine)
+		err := root.CheckExamples()
+		if err == nil {
+			t.Errorf("CheckExamples accepted %q", tt.commandLine)
+			continue
+		}
+		if !strings.Contains(err.Error(), "Deploy without confirmation") || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("CheckExamples(%q) returned %q, want it to name the example and contain %q", tt.commandLine, err, tt.want)
+		}
+	}
+}
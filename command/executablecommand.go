@@ -1,17 +1,19 @@
 package command
 
 import (
-	stdcontext "context"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type executableCommand struct {
 	*baseCommand
 
-	handler            handler
-	args               []Arg
-	flags              []Flag
-	cachedValidatedArgs *ValidatedArgs
+	handler    handler
+	validators []commandValidator
+	args       []Arg
+	flags      []Flag
+	children   map[string]Command
 }
 
 var defaultExecutableCommandHandler = func(ctx *Context, args ValidatedArgs) error {
@@ -24,6 +26,7 @@ func NewExecutableCommand(label, description string) *executableCommand {
 		handler:     defaultExecutableCommandHandler,
 		args:        []Arg{},
 		flags:       []Flag{},
+		children:    make(map[string]Command),
 	}
 }
 
@@ -32,6 +35,11 @@ func (c *executableCommand) Handler(handler handler) *executableCommand {
 	return c
 }
 
+func (c *executableCommand) Validate(validators ...commandValidator) *executableCommand {
+	c.validators = append(c.validators, validators...)
+	return c
+}
+
 func (c *executableCommand) Args(args ...Arg) *executableCommand {
 	c.args = args
 	return c
@@ -42,36 +50,147 @@ func (c *executableCommand) Flags(flags ...Flag) *executableCommand {
 	return c
 }
 
+func (c *executableCommand) AddChild(command Command) *executableCommand {
+	addChild(c, c.children, command)
+	return c
+}
+
+func (c *executableCommand) Hidden() *executableCommand {
+	c.hidden = true
+	return c
+}
+
+func (c *executableCommand) Deprecated(message string) *executableCommand {
+	c.deprecation = c.deprecation.deprecate(message)
+	return c
+}
+
+func (c *executableCommand) FailAfter(version string) *executableCommand {
+	c.deprecation = c.deprecation.failAfterVersion(version)
+	return c
+}
+
+func (c *executableCommand) HelpTemplate(text string) *executableCommand {
+	c.setHelpTemplate(text)
+	return c
+}
+
+func (c *executableCommand) UsageTemplate(text string) *executableCommand {
+	c.setUsageTemplate(text)
+	return c
+}
+
 func (c *executableCommand) execute(ctx *Context, args ValidatedArgs) error {
 	return c.handler(ctx, args)
 }
 
-func (c *executableCommand) run(args []string) error {
-	ctx := newContext(stdcontext.Background(), c)
-	positionalArgs, err := c.separateFlagsFromArgs(args)
-	if err != nil {
+func (c *executableCommand) run(ctx *Context, args []string, inherited []Flag) error {
+	if err := checkCommandDeprecation(ctx, c); err != nil {
 		return err
 	}
-	validatedArgs, err := c.parseAndValidateArgs(positionalArgs)
+	if child, index := findChild(c.children, args, mergeFlags(c.flags, inherited)); child != nil {
+		if err := c.checkLeadingLocalFlags(args[:index], inherited, child); err != nil {
+			return err
+		}
+		if _, err := parseLeadingFlags(ctx, inherited, args[:index]); err != nil {
+			return err
+		}
+		return child.run(ctx, args[index+1:], inherited)
+	}
+
+	ctx.command = c
+	positionalArgs, validatedArgs, flagErr := c.separateFlagsFromArgs(ctx, args, mergeFlags(c.flags, inherited))
+	if flagErr != nil && !ctx.aggregateErrors {
+		return flagErr
+	}
+	if positionalArgs == nil {
+		return flagErr
+	}
+	err := c.parseAndValidateArgs(ctx, positionalArgs, validatedArgs)
+	if flagErr != nil || len(ctx.leadingErrors) > 0 {
+		errs := ctx.takeLeadingErrors()
+		errs.Append(flagErr)
+		errs.Append(err)
+		return errs
+	}
 	if err != nil {
 		return err
 	}
+	if err := c.runValidators(ctx, *validatedArgs); err != nil {
+		return err
+	}
+	if ctx.dryRun {
+		return nil
+	}
 	return c.execute(ctx, *validatedArgs)
 }
 
+func (c *executableCommand) runValidators(ctx *Context, args ValidatedArgs) error {
+	var errs ValidationErrors
+	for _, validate := range c.validators {
+		errs.Append(validate(ctx, args))
+	}
+	return errs.Err()
+}
+
+func findChild(children map[string]Command, args []string, flags []Flag) (Command, int) {
+	if len(children) == 0 {
+		return nil, -1
+	}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || isHelpFlag(arg) {
+			return nil, -1
+		}
+		if !strings.HasPrefix(arg, "-") || len(arg) < 2 {
+			child, ok := children[arg]
+			if !ok {
+				return nil, -1
+			}
+			return child, i
+		}
+		f, hasValue := lookupFlagToken(flags, arg)
+		if f == nil {
+			return nil, -1
+		}
+		if !hasValue && f.Expected() != ValueTypeBool {
+			i++
+		}
+	}
+	return nil, -1
+}
+
+func (c *executableCommand) checkLeadingLocalFlags(args []string, inherited []Flag, child Command) error {
+	for i := 0; i < len(args); i++ {
+		f, hasValue := lookupFlagToken(inherited, args[i])
+		if f == nil {
+			if local, _ := lookupFlagToken(c.flags, args[i]); local != nil {
+				return newFieldError(ErrorKindUnknownFlag, local.Name(), nil, "flag --%s belongs to '%s' and cannot be used before its subcommand '%s'", local.Name(), c.Label(), child.Label())
+			}
+			continue
+		}
+		if !hasValue && f.Expected() != ValueTypeBool {
+			i++
+		}
+	}
+	return nil
+}
 
 type flagMaps struct {
 	byName      map[string]Flag
 	byShorthand map[string]Flag
 }
 
-func (c *executableCommand) buildFlagMaps() flagMaps {
+func buildFlagMaps(flags []Flag) flagMaps {
 	flagMap := make(map[string]Flag)
 	shorthandMap := make(map[string]Flag)
 
-	for i := range c.flags {
-		f := c.flags[i]
+	for i := range flags {
+		f := flags[i]
 		flagMap[f.Name()] = f
+		for _, alias := range f.aliasNames() {
+			flagMap[alias] = f
+		}
 		if f.Shorthand() != "" {
 			shorthandMap[f.Shorthand()] = f
 		}
@@ -80,9 +199,27 @@ func (c *executableCommand) buildFlagMaps() flagMaps {
 	return flagMaps{byName: flagMap, byShorthand: shorthandMap}
 }
 
-func (c *executableCommand) setDefaultFlagValues(validatedArgs *ValidatedArgs) {
-	for _, f := range c.flags {
-		if f.DefaultValue() != nil {
+func setDefaultFlagValues(ctx *Context, flags []Flag, validatedArgs *ValidatedArgs, collector *errorCollector) {
+	for _, f := range flags {
+		if f.DefaultValue() == nil || validatedArgs.HasFlag(f.Name()) || slices.Contains(collector.errs.Fields(), f.Name()) {
+			continue
+		}
+		raw, isString := f.DefaultValue().(string)
+		switch {
+		case isString && f.Expected() == ValueTypePath && raw != "":
+			path, err := f.parse(ctx, raw)
+			if err != nil {
+				if collector.collect(newFieldError(ErrorKindInvalidValue, f.Name(), err, "invalid default value for flag '%s': %v", f.Name(), err)) {
+					return
+				}
+				continue
+			}
+			validatedArgs.setFlag(f.Name(), path)
+		case isString && f.Expected() == ValueTypeInput:
+			if input, err := f.parse(ctx, raw); raw != "" && err == nil {
+				validatedArgs.setFlag(f.Name(), input)
+			}
+		default:
 			validatedArgs.setFlag(f.Name(), f.DefaultValue())
 		}
 	}
@@ -97,7 +234,7 @@ func splitFlagNameValue(flagStr string) (name string, value string, hasValue boo
 	return flagStr, "", false
 }
 
-func (c *executableCommand) parseSingleFlag(f Flag, value string, hasExplicitValue bool, args []string, currentIndex int) (parsedValue interface{}, newIndex int, err error) {
+func parseSingleFlag(ctx *Context, f Flag, value string, hasExplicitValue bool, args []string, currentIndex int) (parsedValue interface{}, newIndex int, err error) {
 	flagValue := value
 	nextIndex := currentIndex
 
@@ -105,30 +242,33 @@ func (c *executableCommand) parseSingleFlag(f Flag, value string, hasExplicitVal
 		flagValue = ""
 	} else if !hasExplicitValue {
 		if currentIndex+1 >= len(args) {
-			return nil, currentIndex, fmt.Errorf("flag --%s requires a value", f.Name())
+			return nil, currentIndex, newFieldError(ErrorKindMissingValue, f.Name(), nil, "flag --%s requires a value", f.Name())
 		}
 		nextIndex++
 		flagValue = args[nextIndex]
 	}
 
-	parsedValue, err = parseValue(flagValue, f.Expected())
+	parsedValue, err = f.parse(ctx, flagValue)
 	if err != nil {
-		return nil, currentIndex, fmt.Errorf("invalid value for flag '%s': %v", f.Name(), err)
+		return nil, nextIndex, newFieldError(ErrorKindInvalidValue, f.Name(), err, "invalid value for flag '%s': %v", f.Name(), err)
 	}
 
 	if err := f.validate(parsedValue); err != nil {
-		return nil, currentIndex, fmt.Errorf("validation failed for flag '%s': %v", f.Name(), err)
+		return nil, nextIndex, newFieldError(ErrorKindValidation, f.Name(), err, "validation failed for flag '%s': %v", f.Name(), err)
 	}
 
 	return parsedValue, nextIndex, nil
 }
 
-func (c *executableCommand) separateFlagsFromArgs(args []string) ([]string, error) {
+func (c *executableCommand) separateFlagsFromArgs(ctx *Context, args []string, flags []Flag) ([]string, *ValidatedArgs, error) {
 	validatedArgs := newValidatedArgs()
 	positionalArgs := []string{}
+	collector := newErrorCollector(ctx)
 
-	maps := c.buildFlagMaps()
-	c.setDefaultFlagValues(validatedArgs)
+	maps := buildFlagMaps(flags)
+	for name, value := range ctx.globalFlagValues {
+		validatedArgs.setFlag(name, value)
+	}
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
@@ -142,22 +282,34 @@ func (c *executableCommand) separateFlagsFromArgs(args []string) ([]string, erro
 			flagName, flagValue, hasValue := splitFlagNameValue(arg[2:])
 
 			if flagName == "help" {
-				c.PrintHelp()
-				return nil, fmt.Errorf("")
+				return nil, nil, requestHelp(ctx, c)
 			}
 
 			f, ok := maps.byName[flagName]
 			if !ok {
-				return nil, fmt.Errorf("unknown flag: --%s", flagName)
+				if collector.collect(newFieldError(ErrorKindUnknownFlag, flagName, nil, "unknown flag: --%s", flagName)) {
+					return nil, nil, collector.err()
+				}
+				continue
 			}
 
-			parsedValue, newIndex, err := c.parseSingleFlag(f, flagValue, hasValue, args, i)
+			parsedValue, newIndex, err := parseSingleFlag(ctx, f, flagValue, hasValue, args, i)
+			i = newIndex
 			if err != nil {
-				return nil, err
+				if collector.collect(err) {
+					return nil, nil, collector.err()
+				}
+				continue
+			}
+
+			if err := checkFlagDeprecation(ctx, f, flagName, true); err != nil {
+				if collector.collect(err) {
+					return nil, nil, collector.err()
+				}
+				continue
 			}
 
 			validatedArgs.setFlag(f.Name(), parsedValue)
-			i = newIndex
 			continue
 		}
 
@@ -165,33 +317,46 @@ func (c *executableCommand) separateFlagsFromArgs(args []string) ([]string, erro
 			shorthand, flagValue, hasValue := splitFlagNameValue(arg[1:])
 
 			if shorthand == "h" {
-				c.PrintHelp()
-				return nil, fmt.Errorf("")
+				return nil, nil, requestHelp(ctx, c)
 			}
 
 			f, ok := maps.byShorthand[shorthand]
 			if !ok {
-				return nil, fmt.Errorf("unknown flag: -%s", shorthand)
+				if collector.collect(newFieldError(ErrorKindUnknownFlag, shorthand, nil, "unknown flag: -%s", shorthand)) {
+					return nil, nil, collector.err()
+				}
+				continue
 			}
 
-			parsedValue, newIndex, err := c.parseSingleFlag(f, flagValue, hasValue, args, i)
+			parsedValue, newIndex, err := parseSingleFlag(ctx, f, flagValue, hasValue, args, i)
+			i = newIndex
 			if err != nil {
-				return nil, err
+				if collector.collect(err) {
+					return nil, nil, collector.err()
+				}
+				continue
+			}
+
+			if err := checkFlagDeprecation(ctx, f, shorthand, false); err != nil {
+				if collector.collect(err) {
+					return nil, nil, collector.err()
+				}
+				continue
 			}
 
 			validatedArgs.setFlag(f.Name(), parsedValue)
-			i = newIndex
 			continue
 		}
 
 		positionalArgs = append(positionalArgs, arg)
 	}
 
-	c.cachedValidatedArgs = validatedArgs
-	return positionalArgs, nil
+	setDefaultFlagValues(ctx, flags, validatedArgs, collector)
+	return positionalArgs, validatedArgs, collector.err()
 }
 
-func (c *executableCommand) parseAndValidateArgs(args []string) (*ValidatedArgs, error) {
+func (c *executableCommand) parseAndValidateArgs(ctx *Context, args []string, validatedArgs *ValidatedArgs) error {
+	collector := newErrorCollector(ctx)
 	requiredCount := 0
 	variadicIndex := -1
 	for i, arg := range c.args {
@@ -200,46 +365,69 @@ func (c *executableCommand) parseAndValidateArgs(args []string) (*ValidatedArgs,
 		}
 		if arg.IsVariadic() {
 			if variadicIndex != -1 {
-				return nil, fmt.Errorf("multiple variadic arguments not allowed")
+				return fmt.Errorf("multiple variadic arguments not allowed")
 			}
 			if i != len(c.args)-1 {
-				return nil, fmt.Errorf("variadic argument must be the last argument")
+				return fmt.Errorf("variadic argument must be the last argument")
 			}
 			variadicIndex = i
 		}
 	}
 
-	if len(args) < requiredCount {
-		c.PrintHelp()
-		return nil, fmt.Errorf("not enough arguments for command: %s", c.Label())
+	if len(args) < requiredCount && !collector.aggregate {
+		c.printUsage(ctx)
+		return newFieldError(ErrorKindMissingArgument, "", nil, "not enough arguments for command: %s", c.Label())
 	}
 
 	if variadicIndex == -1 && len(args) > len(c.args) {
-		c.PrintHelp()
-		return nil, fmt.Errorf("too many arguments for command: %s", c.Label())
-	}
-
-	validatedArgs := c.cachedValidatedArgs
-	if validatedArgs == nil {
-		validatedArgs = newValidatedArgs()
+		c.printUsage(ctx)
+		err := newFieldError(ErrorKindTooManyArguments, "", nil, "too many arguments for command: %s", c.Label())
+		if collector.collect(err) {
+			return collector.err()
+		}
 	}
 
 	for i, arg := range c.args {
 		if arg.IsVariadic() {
 			variadicValues := []interface{}{}
+			variadicValid := true
 			for j := i; j < len(args); j++ {
 				rawValue := args[j]
-				parsedValue, err := parseValue(rawValue, arg.Expected())
+				parsedValues, err := arg.expand(ctx, rawValue)
 
 				if err != nil {
-					return nil, fmt.Errorf("invalid value for variadic argument '%s' at position %d: %v", arg.Label(), j-i, err)
+					variadicValid = false
+					if collector.collect(newFieldError(ErrorKindInvalidValue, arg.Label(), err, "invalid value for variadic argument '%s' at position %d: %v", arg.Label(), j-i, err)) {
+						return collector.err()
+					}
+					continue
 				}
 
-				if err := arg.validate(parsedValue); err != nil {
-					return nil, fmt.Errorf("validation failed for variadic argument '%s' at position %d: %v", arg.Label(), j-i, err)
+				for _, parsedValue := range parsedValues {
+					if err := arg.validate(parsedValue); err != nil {
+						variadicValid = false
+						if collector.collect(newFieldError(ErrorKindValidation, arg.Label(), err, "validation failed for variadic argument '%s' at position %d: %v", arg.Label(), j-i, err)) {
+							return collector.err()
+						}
+					}
 				}
 
-				variadicValues = append(variadicValues, parsedValue)
+				variadicValues = append(variadicValues, parsedValues...)
+			}
+			if !variadicValid {
+				break
+			}
+			if !arg.IsOptional() && len(variadicValues) == 0 {
+				if collector.collect(newFieldError(ErrorKindMissingArgument, arg.Label(), nil, "missing required argument: %s", arg.Label())) {
+					return collector.err()
+				}
+				break
+			}
+			if err := arg.validateVariadic(variadicValues); err != nil {
+				if collector.collect(newFieldError(ErrorKindValidation, arg.Label(), err, "validation failed for variadic argument '%s': %v", arg.Label(), err)) {
+					return collector.err()
+				}
+				break
 			}
 			validatedArgs.setVariadic(arg.Label(), variadicValues)
 			break
@@ -247,32 +435,48 @@ func (c *executableCommand) parseAndValidateArgs(args []string) (*ValidatedArgs,
 
 		if i >= len(args) {
 			if !arg.IsOptional() {
-				return nil, fmt.Errorf("missing required argument: %s", arg.Label())
+				if collector.collect(newFieldError(ErrorKindMissingArgument, arg.Label(), nil, "missing required argument: %s", arg.Label())) {
+					return collector.err()
+				}
 			}
 			continue
 		}
 
 		rawValue := args[i]
-		parsedValue, err := parseValue(rawValue, arg.Expected())
+		parsedValue, err := arg.parse(ctx, rawValue)
 
 		if err != nil {
-			return nil, fmt.Errorf("invalid value for argument '%s': %v", arg.Label(), err)
+			if collector.collect(newFieldError(ErrorKindInvalidValue, arg.Label(), err, "invalid value for argument '%s': %v", arg.Label(), err)) {
+				return collector.err()
+			}
+			continue
 		}
 
 		if err := arg.validate(parsedValue); err != nil {
-			return nil, fmt.Errorf("validation failed for argument '%s': %v", arg.Label(), err)
+			if collector.collect(newFieldError(ErrorKindValidation, arg.Label(), err, "validation failed for argument '%s': %v", arg.Label(), err)) {
+				return collector.err()
+			}
+			continue
 		}
 
 		validatedArgs.set(arg.Label(), parsedValue)
 	}
-	return validatedArgs, nil
+
+	if err := collector.err(); err != nil {
+		if len(args) < requiredCount {
+			c.printUsage(ctx)
+		}
+		return err
+	}
+	return nil
 }
 
 func (c *executableCommand) PrintHelp() {
-	printer := newHelpPrinter()
-	printer.PrintExecutableCommandHelp(c)
+	printer := newHelpPrinter(helpWriter(c))
+	printer.PrintHelp(c)
 }
 
-func (c *executableCommand) inheritGlobalFlags(flags []Flag) {
-	c.flags = append(c.flags, flags...)
+func (c *executableCommand) printUsage(ctx *Context) {
+	printer := newHelpPrinter(ctx.Stdout())
+	printer.PrintUsage(c)
 }
@@ -0,0 +1,24 @@
+package command_test
+
+import (
+	"testing"
+)
+
+func TestChildDispatch(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r)
+
+	runAll(t, root, r, []struct {
+		args []string
+		want string
+	}{
+		{[]string{"remote"}, `remote list "" long=false`},
+		{[]string{"remote", "-l", "origin"}, `remote list "origin" long=true`},
+		{[]string{"remote", "add", "origin", "url"}, "remote add origin url verbose=false"},
+		{[]string{"remote", "--", "add"}, `remote list "add" long=false`},
+	})
+
+	if err := root.Run([]string{"remote", "-l", "add", "origin", "url"}); err == nil {
+		t.Error("expected an error for a parent-only flag before a child name")
+	}
+}
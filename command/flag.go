@@ -1,11 +1,21 @@
 package command
 
+import "slices"
+
 type Flag interface {
 	Name() string
 	Shorthand() string
 	Description() string
 	Expected() ValueType
 	DefaultValue() interface{}
+	CompletionHint() CompletionHint
+	Choices() []string
+	Constraints() []string
+	IsHidden() bool
+	completer() completer
+	deprecationInfo() *deprecation
+	aliasNames() []string
+	parse(ctx *Context, value string) (interface{}, error)
 	validate(value interface{}) error
 	toFlag() flag
 }
@@ -17,6 +27,14 @@ type flag struct {
 	expected     ValueType
 	validators   []validator
 	defaultValue interface{}
+	parser       valueParser
+	completion   CompletionHint
+	choices      []string
+	constraints  []string
+	completeFunc completer
+	hidden       bool
+	deprecation  *deprecation
+	aliases      []string
 }
 
 func (f flag) Name() string {
@@ -39,6 +57,38 @@ func (f flag) DefaultValue() interface{} {
 	return f.defaultValue
 }
 
+func (f flag) CompletionHint() CompletionHint {
+	return f.completion
+}
+
+func (f flag) Choices() []string {
+	return f.choices
+}
+
+func (f flag) Constraints() []string {
+	return f.constraints
+}
+
+func (f flag) IsHidden() bool {
+	return f.hidden
+}
+
+func (f flag) completer() completer {
+	return f.completeFunc
+}
+
+func (f flag) deprecationInfo() *deprecation {
+	return f.deprecation
+}
+
+func (f flag) aliasNames() []string {
+	return f.aliases
+}
+
+func flagHasName(f Flag, name string) bool {
+	return f.Name() == name || slices.Contains(f.aliasNames(), name)
+}
+
 func (f flag) toFlag() flag {
 	return f
 }
@@ -70,6 +120,31 @@ func (f typedFlag[T]) ExtendValidators(validators ...func(T) error) typedFlag[T]
 	return f
 }
 
+func (f typedFlag[T]) Completer(completer completer) typedFlag[T] {
+	f.flag.completeFunc = completer
+	return f
+}
+
+func (f typedFlag[T]) Hidden() typedFlag[T] {
+	f.flag.hidden = true
+	return f
+}
+
+func (f typedFlag[T]) Deprecated(message string) typedFlag[T] {
+	f.flag.deprecation = f.flag.deprecation.deprecate(message)
+	return f
+}
+
+func (f typedFlag[T]) FailAfter(version string) typedFlag[T] {
+	f.flag.deprecation = f.flag.deprecation.failAfterVersion(version)
+	return f
+}
+
+func (f typedFlag[T]) DeprecatedAlias(names ...string) typedFlag[T] {
+	f.flag.aliases = append(slices.Clone(f.flag.aliases), names...)
+	return f
+}
+
 func (f typedFlag[T]) toFlag() flag {
 	return f.flag
 }
@@ -98,6 +173,13 @@ func NewBoolFlag(name string, shorthand string, description string, defaultValue
 	}
 }
 
+func (f flag) parse(ctx *Context, value string) (interface{}, error) {
+	if f.parser != nil {
+		return f.parser(ctx, value)
+	}
+	return parseValue(value, f.expected)
+}
+
 func (f flag) validate(value interface{}) error {
 	return runValidators(f.validators, value)
 }
@@ -0,0 +1,67 @@
+package command
+
+import "strings"
+
+func parseLeadingFlags(ctx *Context, flags []Flag, args []string) ([]string, error) {
+	collector := newErrorCollector(ctx)
+	remaining := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") || len(arg) < 2 || isHelpFlag(arg) || isVersionFlag(arg) {
+			remaining = args[i:]
+			break
+		}
+
+		var f Flag
+		var name string
+		var value string
+		var hasValue bool
+		long := strings.HasPrefix(arg, "--")
+		if long {
+			name, value, hasValue = splitFlagNameValue(arg[2:])
+			f = lookupFlag(flags, func(f Flag) bool { return flagHasName(f, name) })
+			if f == nil {
+				if collector.collect(newFieldError(ErrorKindUnknownFlag, name, nil, "unknown flag: --%s", name)) {
+					return nil, collector.err()
+				}
+				continue
+			}
+		} else {
+			name, value, hasValue = splitFlagNameValue(arg[1:])
+			f = lookupFlag(flags, func(f Flag) bool { return f.Shorthand() != "" && f.Shorthand() == name })
+			if f == nil {
+				if collector.collect(newFieldError(ErrorKindUnknownFlag, name, nil, "unknown flag: -%s", name)) {
+					return nil, collector.err()
+				}
+				continue
+			}
+		}
+
+		parsedValue, newIndex, err := parseSingleFlag(ctx, f, value, hasValue, args, i)
+		i = newIndex
+		if err != nil {
+			if collector.collect(err) {
+				return nil, collector.err()
+			}
+			continue
+		}
+		if err := checkFlagDeprecation(ctx, f, name, long); err != nil {
+			if collector.collect(err) {
+				return nil, collector.err()
+			}
+			continue
+		}
+		ctx.globalFlagValues[f.Name()] = parsedValue
+	}
+	ctx.leadingErrors = append(ctx.leadingErrors, collector.errs...)
+	return remaining, nil
+}
+
+func lookupFlag(flags []Flag, match func(f Flag) bool) Flag {
+	for _, f := range flags {
+		if match(f) {
+			return f
+		}
+	}
+	return nil
+}
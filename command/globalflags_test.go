@@ -0,0 +1,60 @@
+package command_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestGlobalFlagPositions(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r)
+
+	runAll(t, root, r, []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-v", "deploy", "web"}, "deploy web force=false verbose=true env=dev"},
+		{[]string{"deploy", "-v", "web"}, "deploy web force=false verbose=true env=dev"},
+		{[]string{"deploy", "web", "-v", "--env=prod"}, "deploy web force=false verbose=true env=prod"},
+		{[]string{"cluster", "-v", "scale", "3"}, "scale 3 verbose=true"},
+		{[]string{"--verbose", "cluster", "scale", "3"}, "scale 3 verbose=true"},
+		{[]string{"cluster", "scale", "3", "--verbose"}, "scale 3 verbose=true"},
+		{[]string{"remote", "-v", "add", "origin", "url"}, "remote add origin url verbose=true"},
+	})
+}
+
+func TestGlobalFlagErrorsAreAggregated(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r).AggregateErrors()
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--bogus", "deploy", "web", "--also-bogus"}, []string{"bogus", "also-bogus"}},
+		{[]string{"--bogus", "--env=prod", "deploy", "web"}, []string{"bogus"}},
+		{[]string{"--bogus", "nope"}, []string{"bogus", ""}},
+	}
+	for _, tt := range tests {
+		err := root.Run(tt.args)
+		var errs command.ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != len(tt.want) {
+			t.Errorf("Run(%q) returned %v, want %d errors", tt.args, err, len(tt.want))
+			continue
+		}
+		for i, field := range tt.want {
+			if errs[i].Field != field {
+				t.Errorf("Run(%q) error %d is for %q, want %q", tt.args, i, errs[i].Field, field)
+			}
+		}
+	}
+	if len(r.calls) != 0 {
+		t.Errorf("handlers ran despite errors: %q", r.calls)
+	}
+
+	if err := newTestRoot(r).Run([]string{"--bogus", "deploy", "web", "--also-bogus"}); err == nil || err.Error() != "unknown flag: --bogus" {
+		t.Errorf("without AggregateErrors got %v, want only the first unknown flag", err)
+	}
+}
@@ -1,3 +1,7 @@
 package command
 
 type handler func(ctx *Context, args ValidatedArgs) error
+
+type commandValidator func(ctx *Context, args ValidatedArgs) error
+
+type completer func(ctx *Context, partial string, args ValidatedArgs) ([]Completion, Directive)
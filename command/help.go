@@ -0,0 +1,234 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+type HelpView struct {
+	Command        *CommandInfo
+	Name           string
+	CommandPath    string
+	Description    string
+	Usage          string
+	Args           []HelpArg
+	Flags          []HelpFlag
+	InheritedFlags []HelpFlag
+	FlagGroups     []HelpFlagGroup
+	Commands       []HelpCommand
+	Examples       []Example
+	IsRoot         bool
+	Runnable       bool
+	Width          int
+	CommandWidth   int
+	ArgWidth       int
+}
+
+type HelpArg struct {
+	Name        string
+	Type        string
+	Optional    bool
+	Variadic    bool
+	Choices     []string
+	Description string
+}
+
+type HelpFlag struct {
+	Name        string
+	Shorthand   string
+	Type        string
+	Default     string
+	HasDefault  bool
+	Choices     []string
+	Description string
+}
+
+type HelpFlagGroup struct {
+	Title string
+	Flags []HelpFlag
+}
+
+type HelpCommand struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+func newHelpView(info *CommandInfo, width int) HelpView {
+	view := HelpView{
+		Command:     info,
+		Name:        info.Label,
+		CommandPath: info.CommandPath(),
+		Description: info.Description,
+		Usage:       info.Synopsis(),
+		Flags:       helpFlags(info.Flags),
+		Examples:    info.Examples,
+		IsRoot:      info.Parent == nil,
+		Runnable:    info.Runnable,
+		Width:       width,
+	}
+	if !view.IsRoot {
+		view.InheritedFlags = helpFlags(info.InheritedFlags)
+	}
+
+	for _, arg := range info.Args {
+		view.Args = append(view.Args, HelpArg{
+			Name:        arg.Label(),
+			Type:        string(arg.Expected()),
+			Optional:    arg.IsOptional(),
+			Variadic:    arg.IsVariadic(),
+			Choices:     arg.Choices(),
+			Description: arg.Description(),
+		})
+		view.ArgWidth = max(view.ArgWidth, len(arg.Label()))
+	}
+	if len(view.Flags) > 0 {
+		view.FlagGroups = append(view.FlagGroups, HelpFlagGroup{Title: "Flags", Flags: view.Flags})
+	}
+	if len(view.InheritedFlags) > 0 {
+		view.FlagGroups = append(view.FlagGroups, HelpFlagGroup{Title: "Global Flags", Flags: view.InheritedFlags})
+	}
+	for _, child := range info.Children {
+		view.Commands = append(view.Commands, HelpCommand{
+			Name:        child.Label,
+			Description: child.Description,
+			Default:     isDefaultChild(info.command, child.Label),
+		})
+		view.CommandWidth = max(view.CommandWidth, len(child.Label))
+	}
+	return view
+}
+
+func isDefaultChild(parent Command, label string) bool {
+	subcommand, ok := parent.(*Subcommand)
+	return ok && subcommand.defaultChild == label
+}
+
+func helpFlags(flags []Flag) []HelpFlag {
+	entries := []HelpFlag{}
+	for _, f := range flags {
+		entry := HelpFlag{
+			Name:        f.Name(),
+			Shorthand:   f.Shorthand(),
+			Type:        string(f.Expected()),
+			Choices:     f.Choices(),
+			Description: f.Description(),
+		}
+		if f.DefaultValue() != nil {
+			entry.Default = fmt.Sprint(f.DefaultValue())
+			entry.HasDefault = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func newHelpTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(helpFuncs).Parse(text)
+}
+
+var helpFuncs = template.FuncMap{
+	"rpad": func(s string, width int) string {
+		return fmt.Sprintf("%-*s", width, s)
+	},
+	"wrap": func(width int, s string) string {
+		return wrapText(s, width)
+	},
+	"wrapIndent": func(indent int, width int, s string) string {
+		lines := wrapText(s, max(width-indent, minWrapWidth))
+		return strings.ReplaceAll(lines, "\n", "\n"+strings.Repeat(" ", indent))
+	},
+	"indent": func(spaces int, s string) string {
+		padding := strings.Repeat(" ", spaces)
+		return padding + strings.ReplaceAll(s, "\n", "\n"+padding)
+	},
+	"join": func(sep string, items []string) string {
+		return strings.Join(items, sep)
+	},
+	"add": func(a int, b int) int {
+		return a + b
+	},
+	"trim":  strings.TrimSpace,
+	"upper": strings.ToUpper,
+}
+
+const minWrapWidth = 20
+
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := []string{}
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+const defaultHelpTemplate = `{{ define "flags" }}
+{{- range .FlagGroups }}
+
+{{ .Title }}:
+{{- range .Flags }}
+  --{{ .Name }}{{ if .Shorthand }}, -{{ .Shorthand }}{{ end }} ({{ .Type }}){{ if .HasDefault }} (default: {{ .Default }}){{ end }}
+      {{ wrapIndent 6 $.Width .Description }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- define "commands" }}
+{{- if .Commands }}
+
+{{ if .IsRoot }}Available Commands:{{ else }}Available Subcommands:{{ end }}
+{{- range .Commands }}{{ $description := .Description }}{{ if .Default }}{{ $description = printf "%s (default)" .Description }}{{ end }}
+  {{ rpad .Name $.CommandWidth }}  {{ wrapIndent (add $.CommandWidth 4) $.Width $description }}
+{{- end }}
+{{- end }}
+{{- end -}}
+Usage: {{ .Usage }}
+
+{{ wrap .Width .Description }}
+{{- if .Runnable }}
+{{- template "flags" . }}
+{{- if .Args }}
+
+Arguments:
+{{- range .Args }}{{ $detail := printf "(%s) - %s" .Type .Description }}{{ if .Optional }}{{ $detail = printf "(%s) (optional) - %s" .Type .Description }}{{ end }}
+  {{ rpad .Name $.ArgWidth }}  {{ wrapIndent (add $.ArgWidth 4) $.Width $detail }}
+{{- end }}
+{{- end }}
+{{- template "commands" . }}
+{{- else }}
+{{- template "commands" . }}
+{{- template "flags" . }}
+{{- end }}
+{{- if .Examples }}
+
+Examples:
+{{- range .Examples }}
+  {{ wrapIndent 2 $.Width .Title }}
+    $ {{ .CommandLine }}
+{{- end }}
+{{- end }}
+`
+
+const defaultUsageTemplate = `Usage: {{ .Usage }}
+
+Run '{{ .CommandPath }} --help' for more information.
+`
+
+var (
+	defaultHelp  = template.Must(newHelpTemplate("help", defaultHelpTemplate))
+	defaultUsage = template.Must(newHelpTemplate("usage", defaultUsageTemplate))
+)
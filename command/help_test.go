@@ -0,0 +1,24 @@
+package command_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestHelpRendersFlagGroupTitles(t *testing.T) {
+	var out strings.Builder
+	root := newTestRoot(&recorder{}).Stdout(&out)
+
+	if err := root.Run([]string{"deploy", "--help"}); err != nil && !errors.Is(err, command.ErrHelp) {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	help := out.String()
+	flags := strings.Index(help, "\n\nFlags:\n  --force")
+	global := strings.Index(help, "\n\nGlobal Flags:\n  --verbose")
+	if flags < 0 || global < 0 || global < flags {
+		t.Errorf("help does not list local flags under Flags: and inherited flags under Global Flags:\n%s", help)
+	}
+}
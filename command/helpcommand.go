@@ -0,0 +1,106 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const helpCommandName = "help"
+
+var ErrHelp = errors.New("help requested")
+
+type HelpRequestedError struct {
+	Command Command
+}
+
+func (e *HelpRequestedError) Error() string {
+	return ErrHelp.Error()
+}
+
+func (e *HelpRequestedError) Is(target error) bool {
+	return target == ErrHelp
+}
+
+func isHelpFlag(arg string) bool {
+	return arg == "--help" || arg == "-h"
+}
+
+func requestHelp(ctx *Context, cmd Command) error {
+	newHelpPrinter(ctx.Stdout()).PrintHelp(cmd)
+	return &HelpRequestedError{Command: cmd}
+}
+
+func newHelpCommand(root *RootCommand) *executableCommand {
+	command := NewExecutableCommand(helpCommandName, "Show help for a command").
+		Args(NewStringArg("command", "Path of the command to describe").
+			AsOptional().
+			AsVariadic().
+			Completer(func(ctx *Context, partial string, args ValidatedArgs) ([]Completion, Directive) {
+				cmd, err := root.resolvePath(args.VariadicStrings("command"))
+				if err != nil {
+					return nil, DirectiveNoFileComp
+				}
+				return completeChildren(ctx, commandChildren(cmd), []string{partial}, nil)
+			})).
+		Handler(func(ctx *Context, args ValidatedArgs) error {
+			cmd, err := root.resolvePath(args.VariadicStrings("command"))
+			if err != nil {
+				return err
+			}
+			newHelpPrinter(ctx.Stdout()).PrintHelp(cmd)
+			return nil
+		})
+	command.builtin = true
+	return command
+}
+
+func (c *RootCommand) resolvePath(path []string) (Command, error) {
+	var node Command = c
+	for i, label := range path {
+		children := commandChildren(node)
+		child, ok := children[label]
+		if !ok {
+			err := fmt.Sprintf("unknown command %q for %q", label, strings.Join(append([]string{c.Label()}, path[:i]...), " "))
+			if suggestions := suggestLabels(children, label); len(suggestions) > 0 {
+				err += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t")
+			}
+			return nil, fmt.Errorf("%s", err)
+		}
+		node = child
+	}
+	return node, nil
+}
+
+func suggestLabels(children map[string]Command, name string) []string {
+	suggestions := []string{}
+	for _, label := range sortedChildLabels(children) {
+		if isHidden(children[label]) {
+			continue
+		}
+		if strings.HasPrefix(label, name) || levenshtein(label, name) <= 2 {
+			suggestions = append(suggestions, label)
+		}
+	}
+	return suggestions
+}
+
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -4,73 +4,80 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
+	"text/template"
 )
 
+const defaultHelpWidth = 80
+
 type helpPrinter struct {
 	writer io.Writer
+	width  int
 }
 
-func newHelpPrinter() *helpPrinter {
+func newHelpPrinter(writer io.Writer) *helpPrinter {
 	return &helpPrinter{
-		writer: os.Stdout,
+		writer: writer,
+		width:  outputWidth(writer),
+	}
+}
+
+func helpWriter(cmd Command) io.Writer {
+	if root := rootOf(cmd); root != nil && root.stdout != nil {
+		return root.stdout
 	}
+	return os.Stdout
 }
 
-func (h *helpPrinter) PrintRootCommandHelp(cmd *RootCommand) {
-	fmt.Fprintf(h.writer, "Usage: %s [command] [options]\n\n", cmd.Label())
-	fmt.Fprintf(h.writer, "%s\n\n", cmd.Description())
-	fmt.Fprintf(h.writer, "Available Commands:\n")
-	for _, child := range cmd.children {
-		fmt.Fprintf(h.writer, "  %-15s %s\n", child.Label(), child.Description())
+func outputWidth(writer io.Writer) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
 	}
+	if file, ok := writer.(*os.File); ok {
+		if width := terminalWidth(file.Fd()); width > 0 {
+			return width
+		}
+	}
+	return defaultHelpWidth
+}
+
+func (h *helpPrinter) PrintHelp(cmd Command) {
+	h.render(helpTemplateOf(cmd), cmd)
+}
+
+func (h *helpPrinter) PrintUsage(cmd Command) {
+	h.render(usageTemplateOf(cmd), cmd)
 }
 
-func (h *helpPrinter) PrintSubcommandHelp(cmd *Subcommand) {
-	fmt.Fprintf(h.writer, "Subcommand: %s\n", cmd.Label())
-	fmt.Fprintf(h.writer, "%s\n\n", cmd.Description())
-	fmt.Fprintf(h.writer, "Available Subcommands:\n")
-	for _, child := range cmd.children {
-		fmt.Fprintf(h.writer, "  %-15s %s\n", child.Label(), child.Description())
+func (h *helpPrinter) render(tmpl *template.Template, cmd Command) {
+	if err := tmpl.Execute(h.writer, newHelpView(commandInfo(cmd), h.width)); err != nil {
+		fmt.Fprintf(h.writer, "error rendering help: %v\n", err)
 	}
 }
 
-func (h *helpPrinter) PrintExecutableCommandHelp(cmd *executableCommand) {
-	fmt.Fprintf(h.writer, "Command: %s\n", cmd.Label())
-	fmt.Fprintf(h.writer, "%s\n\n", cmd.Description())
+func helpTemplateOf(cmd Command) *template.Template {
+	for node := cmd; node != nil; node = node.Parent() {
+		if tmpl := node.base().helpTemplate; tmpl != nil {
+			return tmpl
+		}
+	}
+	return defaultHelp
+}
 
-	if len(cmd.flags) > 0 {
-		fmt.Fprintf(h.writer, "Flags:\n")
-		for _, flag := range cmd.flags {
-			shorthandDisplay := ""
-			if flag.Shorthand() != "" {
-				shorthandDisplay = fmt.Sprintf(", -%s", flag.Shorthand())
-			}
-			defaultDisplay := ""
-			if flag.DefaultValue() != nil {
-				defaultDisplay = fmt.Sprintf(" (default: %v)", flag.DefaultValue())
-			}
-			fmt.Fprintf(h.writer, "  --%s%s (%s)%s\n      %s\n",
-				flag.Name(),
-				shorthandDisplay,
-				flag.Expected(),
-				defaultDisplay,
-				flag.Description())
+func usageTemplateOf(cmd Command) *template.Template {
+	for node := cmd; node != nil; node = node.Parent() {
+		if tmpl := node.base().usageTemplate; tmpl != nil {
+			return tmpl
 		}
-		fmt.Fprintf(h.writer, "\n")
 	}
+	return defaultUsage
+}
 
-	if len(cmd.args) > 0 {
-		fmt.Fprintf(h.writer, "Arguments:\n")
-		for _, arg := range cmd.args {
-			optionalMarker := ""
-			if arg.IsOptional() {
-				optionalMarker = " (optional)"
-			}
-			fmt.Fprintf(h.writer, "  %-15s (%s)%s - %s\n",
-				arg.Label(),
-				arg.Expected(),
-				optionalMarker,
-				arg.Description())
+func rootOf(cmd Command) *RootCommand {
+	for node := cmd; node != nil; node = node.Parent() {
+		if root, ok := node.(*RootCommand); ok {
+			return root
 		}
 	}
+	return nil
 }
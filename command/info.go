@@ -0,0 +1,172 @@
+package command
+
+import (
+	"slices"
+	"strings"
+)
+
+type CommandInfo struct {
+	Label          string
+	Description    string
+	Path           []string
+	Args           []Arg
+	Flags          []Flag
+	InheritedFlags []Flag
+	Examples       []Example
+	Runnable       bool
+	Parent         *CommandInfo
+	Children       []*CommandInfo
+
+	command Command
+}
+
+func (i *CommandInfo) CommandPath() string {
+	return strings.Join(i.Path, " ")
+}
+
+func (i *CommandInfo) Synopsis() string {
+	parts := []string{i.CommandPath()}
+	if len(i.Flags)+len(i.InheritedFlags) > 0 {
+		parts = append(parts, "[flags]")
+	}
+	if !i.Runnable {
+		if len(i.Children) > 0 {
+			parts = append(parts, "<command>")
+		}
+		return strings.Join(parts, " ")
+	}
+	for _, arg := range i.Args {
+		parts = append(parts, argSynopsis(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+func argSynopsis(arg Arg) string {
+	switch {
+	case arg.IsOptional() && arg.IsVariadic():
+		return "[" + arg.Label() + "...]"
+	case arg.IsOptional():
+		return "[" + arg.Label() + "]"
+	case arg.IsVariadic():
+		return "<" + arg.Label() + ">..."
+	default:
+		return "<" + arg.Label() + ">"
+	}
+}
+
+func (i *CommandInfo) Walk(fn func(info *CommandInfo) error) error {
+	if err := fn(i); err != nil {
+		return err
+	}
+	for _, child := range i.Children {
+		if err := child.Walk(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RootCommand) Info() *CommandInfo {
+	return commandInfo(c)
+}
+
+func commandInfo(cmd Command) *CommandInfo {
+	chain := []Command{cmd}
+	for parent := cmd.Parent(); parent != nil; parent = parent.Parent() {
+		chain = append([]Command{parent}, chain...)
+	}
+
+	var info *CommandInfo
+	inherited := []Flag{}
+	for _, node := range chain {
+		info = newCommandInfo(node, info, inherited)
+		local := info.Flags
+		if root, ok := node.(*RootCommand); ok {
+			local = visibleFlags(root.globalFlags)
+		}
+		inherited = childFlags(node, local, inherited)
+	}
+	info.Children = buildChildInfos(info, commandChildren(cmd), inherited)
+	return info
+}
+
+func newCommandInfo(cmd Command, parent *CommandInfo, inherited []Flag) *CommandInfo {
+	info := &CommandInfo{
+		Label:          cmd.Label(),
+		Description:    cmd.Description(),
+		Path:           []string{cmd.Label()},
+		InheritedFlags: inherited,
+		Examples:       cmd.base().examples,
+		Parent:         parent,
+		command:        cmd,
+	}
+	if parent != nil {
+		info.Path = append(slices.Clone(parent.Path), cmd.Label())
+	}
+
+	switch c := cmd.(type) {
+	case *RootCommand:
+		info.Flags = visibleFlags(mergeFlags(c.globalFlags, c.versionFlags()))
+	case *Subcommand:
+		info.Flags = []Flag{}
+	case *executableCommand:
+		info.Runnable = true
+		info.Args = c.args
+		info.Flags = localFlags(visibleFlags(c.flags), inherited)
+	}
+	return info
+}
+
+func buildChildInfos(parent *CommandInfo, children map[string]Command, inherited []Flag) []*CommandInfo {
+	infos := []*CommandInfo{}
+	for _, label := range sortedChildLabels(children) {
+		child := children[label]
+		if isHidden(child) {
+			continue
+		}
+		info := newCommandInfo(child, parent, inherited)
+		info.Children = buildChildInfos(info, commandChildren(child), childFlags(child, info.Flags, inherited))
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func commandChildren(cmd Command) map[string]Command {
+	switch c := cmd.(type) {
+	case *RootCommand:
+		return c.children
+	case *Subcommand:
+		return c.children
+	case *executableCommand:
+		return c.children
+	default:
+		return nil
+	}
+}
+
+func childFlags(cmd Command, local []Flag, inherited []Flag) []Flag {
+	if _, ok := cmd.(*executableCommand); ok {
+		return inherited
+	}
+	return mergeFlags(local, inherited)
+}
+
+func visibleFlags(flags []Flag) []Flag {
+	visible := []Flag{}
+	for _, f := range flags {
+		if !f.IsHidden() {
+			visible = append(visible, f)
+		}
+	}
+	return visible
+}
+
+func localFlags(flags []Flag, inherited []Flag) []Flag {
+	local := []Flag{}
+	for _, f := range flags {
+		if !slices.ContainsFunc(inherited, func(i Flag) bool { return i.Name() == f.Name() }) {
+			local = append(local, f)
+		}
+	}
+	return local
+}
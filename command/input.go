@@ -0,0 +1,226 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const stdinInputName = "-"
+
+type Input struct {
+	name    string
+	open    func() (io.ReadCloser, error)
+	maxSize int64
+
+	reader   io.ReadCloser
+	read     int64
+	content  []byte
+	buffered *bytes.Reader
+	consumed bool
+	err      error
+}
+
+func newInput(ctx *Context, value string, maxSize int64) *Input {
+	input := &Input{name: value, maxSize: maxSize}
+	switch {
+	case value == stdinInputName:
+		stdin := ctx.Stdin()
+		input.open = func() (io.ReadCloser, error) {
+			return io.NopCloser(stdin), nil
+		}
+	case strings.HasPrefix(value, "@"):
+		path := ctx.ResolvePath(value[1:])
+		input.name = path
+		input.open = func() (io.ReadCloser, error) {
+			return os.Open(path)
+		}
+	default:
+		input.open = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(value)), nil
+		}
+	}
+	return input
+}
+
+func (i *Input) Name() string {
+	return i.name
+}
+
+func (i *Input) IsStdin() bool {
+	return i.name == stdinInputName
+}
+
+func (i *Input) Read(p []byte) (int, error) {
+	if i.buffered != nil {
+		return i.buffered.Read(p)
+	}
+	if i.err != nil {
+		return 0, i.err
+	}
+	if i.consumed {
+		return 0, io.EOF
+	}
+	if i.reader == nil {
+		reader, err := i.open()
+		if err != nil {
+			i.err = fmt.Errorf("cannot open input %s: %v", i.name, err)
+			return 0, i.err
+		}
+		i.reader = reader
+	}
+
+	n, err := i.reader.Read(p)
+	i.read += int64(n)
+	if i.maxSize > 0 && i.read > i.maxSize {
+		n -= int(i.read - i.maxSize)
+		i.Close()
+		i.err = fmt.Errorf("input %s exceeds the maximum size of %d bytes", i.name, i.maxSize)
+		return n, i.err
+	}
+	if err == io.EOF {
+		i.Close()
+	} else if err != nil {
+		i.err = err
+	}
+	return n, err
+}
+
+func (i *Input) Bytes() ([]byte, error) {
+	if i.content != nil {
+		return i.content, nil
+	}
+	content, err := io.ReadAll(i)
+	if err != nil {
+		return nil, err
+	}
+	i.content = content
+	i.buffered = bytes.NewReader(content)
+	return content, nil
+}
+
+func (i *Input) Close() error {
+	i.consumed = true
+	if i.reader == nil {
+		return nil
+	}
+	err := i.reader.Close()
+	i.reader = nil
+	return err
+}
+
+func inputParser(maxSize int64) valueParser {
+	return func(ctx *Context, value string) (interface{}, error) {
+		if value == "@" {
+			return nil, fmt.Errorf("missing file path after '@'")
+		}
+		return newInput(ctx, value, maxSize), nil
+	}
+}
+
+func validateInput(value interface{}) error {
+	_, ok := value.(*Input)
+	if !ok {
+		return fmt.Errorf("expected input, got %T", value)
+	}
+	return nil
+}
+
+type inputArg struct {
+	typedArg[*Input]
+}
+
+func NewInputArg(label string, description string) inputArg {
+	a := inputArg{
+		typedArg: typedArg[*Input]{
+			arg: NewArg(label, description, ValueTypeInput, validateInput),
+		},
+	}
+	a.arg.parser = inputParser(0)
+	a.arg.completion = CompletionHintFile
+	return a
+}
+
+func (a inputArg) MaxSize(bytes int64) inputArg {
+	a.arg.parser = inputParser(bytes)
+	a.arg.constraints = []string{fmt.Sprintf("at most %d bytes", bytes)}
+	return a
+}
+
+func (a inputArg) ExtendValidators(validators ...func(*Input) error) inputArg {
+	a.typedArg = a.typedArg.ExtendValidators(validators...)
+	return a
+}
+
+func (a inputArg) ExtendVariadicValidators(validators ...func([]*Input) error) inputArg {
+	a.typedArg = a.typedArg.ExtendVariadicValidators(validators...)
+	return a
+}
+
+func (a inputArg) Completer(completer completer) inputArg {
+	a.typedArg = a.typedArg.Completer(completer)
+	return a
+}
+
+func (a inputArg) AsOptional() inputArg {
+	a.typedArg = a.typedArg.AsOptional()
+	return a
+}
+
+func (a inputArg) AsVariadic() inputArg {
+	a.typedArg = a.typedArg.AsVariadic()
+	return a
+}
+
+type inputFlag struct {
+	typedFlag[*Input]
+}
+
+func NewInputFlag(name string, shorthand string, description string, defaultValue string) inputFlag {
+	f := inputFlag{
+		typedFlag: typedFlag[*Input]{
+			flag: NewFlag(name, shorthand, description, ValueTypeInput, defaultValue, validateInput),
+		},
+	}
+	f.flag.parser = inputParser(0)
+	f.flag.completion = CompletionHintFile
+	return f
+}
+
+func (f inputFlag) MaxSize(bytes int64) inputFlag {
+	f.flag.parser = inputParser(bytes)
+	f.flag.constraints = []string{fmt.Sprintf("at most %d bytes", bytes)}
+	return f
+}
+
+func (f inputFlag) ExtendValidators(validators ...func(*Input) error) inputFlag {
+	f.typedFlag = f.typedFlag.ExtendValidators(validators...)
+	return f
+}
+
+func (f inputFlag) Completer(completer completer) inputFlag {
+	f.typedFlag = f.typedFlag.Completer(completer)
+	return f
+}
+
+func (f inputFlag) Hidden() inputFlag {
+	f.typedFlag = f.typedFlag.Hidden()
+	return f
+}
+
+func (f inputFlag) Deprecated(message string) inputFlag {
+	f.typedFlag = f.typedFlag.Deprecated(message)
+	return f
+}
+
+func (f inputFlag) FailAfter(version string) inputFlag {
+	f.typedFlag = f.typedFlag.FailAfter(version)
+	return f
+}
+
+func (f inputFlag) DeprecatedAlias(names ...string) inputFlag {
+	f.typedFlag = f.typedFlag.DeprecatedAlias(names...)
+	return f
+}
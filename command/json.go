@@ -0,0 +1,384 @@
+package command
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type JSONPointerError struct {
+	Pointer string
+	Err     error
+}
+
+func (e *JSONPointerError) Error() string {
+	if e.Pointer == "" {
+		return fmt.Sprintf("json: %v", e.Err)
+	}
+	return fmt.Sprintf("json at %s: %v", e.Pointer, e.Err)
+}
+
+func (e *JSONPointerError) Unwrap() error {
+	return e.Err
+}
+
+func NewJSONPointerError(pointer string, format string, args ...interface{}) *JSONPointerError {
+	return &JSONPointerError{Pointer: pointer, Err: fmt.Errorf(format, args...)}
+}
+
+func JSONPointer(tokens ...string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+		b.WriteString("/")
+		b.WriteString(token)
+	}
+	return b.String()
+}
+
+func jsonParser[T any](disallowUnknownFields bool) valueParser {
+	return func(ctx *Context, value string) (interface{}, error) {
+		data, err := newInput(ctx, value, 0).Bytes()
+		if err != nil {
+			return nil, err
+		}
+		return decodeJSON[T](data, disallowUnknownFields)
+	}
+}
+
+func decodeJSON[T any](data []byte, disallowUnknownFields bool) (T, error) {
+	var target T
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return target, &JSONPointerError{Err: describeJSONSyntaxError(data, err)}
+	}
+	if decoder.More() {
+		return target, &JSONPointerError{Err: errors.New("unexpected data after top-level value")}
+	}
+
+	if err := checkJSONShape(tree, reflect.TypeOf(&target).Elem(), "", disallowUnknownFields); err != nil {
+		return target, err
+	}
+
+	decoder = json.NewDecoder(bytes.NewReader(data))
+	if disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(&target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return target, &JSONPointerError{
+				Pointer: JSONPointer(strings.Split(typeErr.Field, ".")...),
+				Err:     fmt.Errorf("cannot use %s as %s", typeErr.Value, typeErr.Type),
+			}
+		}
+		return target, &JSONPointerError{Err: err}
+	}
+	return target, nil
+}
+
+func describeJSONSyntaxError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+	line, column := 1, 1
+	// Offset counts the offending byte, so the position is the byte before it.
+	for _, b := range data[:min(max(int(syntaxErr.Offset)-1, 0), len(data))] {
+		if b == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+	return fmt.Errorf("%v (line %d, column %d)", syntaxErr, line, column)
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonNumberType      = reflect.TypeOf(json.Number(""))
+)
+
+func checkJSONShape(value interface{}, t reflect.Type, pointer string, disallowUnknownFields bool) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value == nil || t == jsonNumberType {
+		return nil
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+	if _, isString := value.(string); isString && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		fields := jsonStructFields(t)
+		for key, item := range object {
+			field, ok := lookupJSONField(fields, key)
+			if !ok {
+				if disallowUnknownFields {
+					return NewJSONPointerError(pointer+JSONPointer(key), "unknown field %q", key)
+				}
+				continue
+			}
+			if err := checkJSONShape(item, field.typ, pointer+JSONPointer(key), disallowUnknownFields); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		for key, item := range object {
+			if err := checkJSONShape(item, t.Elem(), pointer+JSONPointer(key), disallowUnknownFields); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if _, isString := value.(string); isString && t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		for i, item := range items {
+			if err := checkJSONShape(item, t.Elem(), pointer+JSONPointer(strconv.Itoa(i)), disallowUnknownFields); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		return nil
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := value.(json.Number)
+		if !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		if _, err := strconv.ParseInt(number.String(), 10, t.Bits()); err != nil {
+			return NewJSONPointerError(pointer, "%s is not a valid %s", number, t)
+		}
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := value.(json.Number)
+		if !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		if _, err := strconv.ParseUint(number.String(), 10, t.Bits()); err != nil {
+			return NewJSONPointerError(pointer, "%s is not a valid %s", number, t)
+		}
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			return jsonTypeMismatch(pointer, value, t)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func jsonTypeMismatch(pointer string, value interface{}, t reflect.Type) error {
+	return NewJSONPointerError(pointer, "cannot use %s as %s", jsonKind(value), t)
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+}
+
+func jsonStructFields(t reflect.Type) []jsonField {
+	fields := []jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, jsonStructFields(embedded)...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{name: name, typ: field.Type})
+	}
+	return fields
+}
+
+func lookupJSONField(fields []jsonField, key string) (jsonField, bool) {
+	for _, field := range fields {
+		if field.name == key {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field, true
+		}
+	}
+	return jsonField{}, false
+}
+
+func jsonValidator[T any](value interface{}) error {
+	_, ok := value.(T)
+	if !ok && value != nil {
+		return fmt.Errorf("expected %T, got %T", *new(T), value)
+	}
+	return nil
+}
+
+type jsonArg[T any] struct {
+	typedArg[T]
+}
+
+func NewJSONArg[T any](label string, description string) jsonArg[T] {
+	a := jsonArg[T]{
+		typedArg: typedArg[T]{
+			arg: NewArg(label, description, ValueTypeJSON, jsonValidator[T]),
+		},
+	}
+	a.arg.parser = jsonParser[T](false)
+	return a
+}
+
+func (a jsonArg[T]) DisallowUnknownFields() jsonArg[T] {
+	a.arg.parser = jsonParser[T](true)
+	a.arg.constraints = []string{"no unknown fields"}
+	return a
+}
+
+func (a jsonArg[T]) ExtendValidators(validators ...func(T) error) jsonArg[T] {
+	a.typedArg = a.typedArg.ExtendValidators(validators...)
+	return a
+}
+
+func (a jsonArg[T]) ExtendVariadicValidators(validators ...func([]T) error) jsonArg[T] {
+	a.typedArg = a.typedArg.ExtendVariadicValidators(validators...)
+	return a
+}
+
+func (a jsonArg[T]) Completer(completer completer) jsonArg[T] {
+	a.typedArg = a.typedArg.Completer(completer)
+	return a
+}
+
+func (a jsonArg[T]) AsOptional() jsonArg[T] {
+	a.typedArg = a.typedArg.AsOptional()
+	return a
+}
+
+func (a jsonArg[T]) AsVariadic() jsonArg[T] {
+	a.typedArg = a.typedArg.AsVariadic()
+	return a
+}
+
+type jsonFlag[T any] struct {
+	typedFlag[T]
+}
+
+func NewJSONFlag[T any](name string, shorthand string, description string) jsonFlag[T] {
+	f := jsonFlag[T]{
+		typedFlag: typedFlag[T]{
+			flag: NewFlag(name, shorthand, description, ValueTypeJSON, nil, jsonValidator[T]),
+		},
+	}
+	f.flag.parser = jsonParser[T](false)
+	return f
+}
+
+func (f jsonFlag[T]) DisallowUnknownFields() jsonFlag[T] {
+	f.flag.parser = jsonParser[T](true)
+	f.flag.constraints = []string{"no unknown fields"}
+	return f
+}
+
+func (f jsonFlag[T]) ExtendValidators(validators ...func(T) error) jsonFlag[T] {
+	f.typedFlag = f.typedFlag.ExtendValidators(validators...)
+	return f
+}
+
+func (f jsonFlag[T]) Completer(completer completer) jsonFlag[T] {
+	f.typedFlag = f.typedFlag.Completer(completer)
+	return f
+}
+
+func (f jsonFlag[T]) Hidden() jsonFlag[T] {
+	f.typedFlag = f.typedFlag.Hidden()
+	return f
+}
+
+func (f jsonFlag[T]) Deprecated(message string) jsonFlag[T] {
+	f.typedFlag = f.typedFlag.Deprecated(message)
+	return f
+}
+
+func (f jsonFlag[T]) FailAfter(version string) jsonFlag[T] {
+	f.typedFlag = f.typedFlag.FailAfter(version)
+	return f
+}
+
+func (f jsonFlag[T]) DeprecatedAlias(names ...string) jsonFlag[T] {
+	f.typedFlag = f.typedFlag.DeprecatedAlias(names...)
+	return f
+}
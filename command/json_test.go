@@ -0,0 +1,153 @@
+package command_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+type jsonTarget struct {
+	Name  string            `json:"name"`
+	Port  int8              `json:"port"`
+	Tags  []string          `json:"tags"`
+	Rules []jsonRule        `json:"rules"`
+	Meta  map[string]int    `json:"meta"`
+	Extra map[string]string `json:"a/b~c"`
+	jsonEmbedded
+}
+
+type jsonRule struct {
+	Match   string `json:"match"`
+	Enabled bool   `json:"enabled"`
+}
+
+type jsonEmbedded struct {
+	Owner string `json:"owner"`
+}
+
+func runJSON[T any](t *testing.T, arg command.Arg, value string) (T, error) {
+	t.Helper()
+	var got T
+	cmd := command.NewExecutableCommand("apply", "Apply a document").
+		Args(arg).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			var err error
+			got, err = command.ArgValue[T](args, "doc")
+			return err
+		})
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "doc.json"), []byte(`{"name": "from file"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	root := command.NewRootCommand("app", "Test application").
+		WorkingDir(dir).
+		Stdin(strings.NewReader(`{"name": "from stdin", "port": 8}`)).
+		AddChild(cmd)
+	err := root.Run([]string{"apply", value})
+	return got, err
+}
+
+func TestJSONArgDecodes(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`{"name": "inline", "owner": "ops", "rules": [{"match": "*", "enabled": true}]}`, "inline"},
+		{"@doc.json", "from file"},
+		{"-", "from stdin"},
+		{`{"NAME": "case insensitive"}`, "case insensitive"},
+	}
+	for _, tt := range tests {
+		got, err := runJSON[jsonTarget](t, command.NewJSONArg[jsonTarget]("doc", "Document"), tt.value)
+		if err != nil {
+			t.Errorf("%s: returned %v", tt.value, err)
+			continue
+		}
+		if got.Name != tt.want {
+			t.Errorf("%s: name = %q, want %q", tt.value, got.Name, tt.want)
+		}
+	}
+
+	got, err := runJSON[any](t, command.NewJSONArg[any]("doc", "Document"), `[1, {"a": null}]`)
+	if err != nil {
+		t.Fatalf("decoding into any returned %v", err)
+	}
+	if items, ok := got.([]interface{}); !ok || len(items) != 2 {
+		t.Errorf("decoded %#v, want a two-item array", got)
+	}
+}
+
+func TestJSONArgPointerErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		strict  bool
+		pointer string
+		message string
+	}{
+		{"top-level type", `[1]`, false, "", "cannot use array as command_test.jsonTarget"},
+		{"nested field", `{"rules": [{"match": "a"}, {"enabled": "yes"}]}`, false, "/rules/1/enabled", "cannot use string as bool"},
+		{"array element", `{"tags": ["a", 2]}`, false, "/tags/1", "cannot use number as string"},
+		{"map value", `{"meta": {"x/y": "one"}}`, false, "/meta/x~1y", "cannot use string as int"},
+		{"escaped key", `{"a/b~c": {"k": 1}}`, false, "/a~1b~0c/k", "cannot use number as string"},
+		{"embedded field", `{"owner": 5}`, false, "/owner", "cannot use number as string"},
+		{"integer overflow", `{"port": 300}`, false, "/port", "300 is not a valid int8"},
+		{"fraction for integer", `{"port": 1.5}`, false, "/port", "1.5 is not a valid int8"},
+		{"unknown field allowed", `{"name": "x", "bogus": 1}`, false, "", ""},
+		{"unknown field rejected", `{"name": "x", "rules": [{"bogus": 1}]}`, true, "/rules/0/bogus", `unknown field "bogus"`},
+		{"syntax error", "{\n  \"name\": \"x\",\n  oops\n}", false, "", "(line 3, column 3)"},
+		{"syntax error on the first line", `{"name" "x"}`, false, "", "(line 1, column 9)"},
+		{"trailing data", `{} {}`, false, "", "unexpected data after top-level value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arg := command.NewJSONArg[jsonTarget]("doc", "Document")
+			if tt.strict {
+				arg = arg.DisallowUnknownFields()
+			}
+			_, err := runJSON[jsonTarget](t, arg, tt.value)
+			if tt.message == "" {
+				if err != nil {
+					t.Errorf("returned %v, want nil", err)
+				}
+				return
+			}
+			var pointerErr *command.JSONPointerError
+			if !errors.As(err, &pointerErr) {
+				t.Fatalf("returned %v, want a JSONPointerError", err)
+			}
+			if pointerErr.Pointer != tt.pointer {
+				t.Errorf("pointer = %q, want %q", pointerErr.Pointer, tt.pointer)
+			}
+			if !strings.Contains(pointerErr.Err.Error(), tt.message) {
+				t.Errorf("error = %q, want it to contain %q", pointerErr.Err, tt.message)
+			}
+		})
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		tokens []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"a", "0", "b"}, "/a/0/b"},
+		{[]string{"a/b", "m~n", "~1"}, "/a~1b/m~0n/~01"},
+		{[]string{""}, "/"},
+	}
+	for _, tt := range tests {
+		if got := command.JSONPointer(tt.tokens...); got != tt.want {
+			t.Errorf("JSONPointer(%q) = %q, want %q", tt.tokens, got, tt.want)
+		}
+	}
+
+	err := command.NewJSONPointerError("/a", "bad value %d", 3)
+	if err.Error() != "json at /a: bad value 3" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
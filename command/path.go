@@ -0,0 +1,371 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type pathOptions struct {
+	mustExist    bool
+	mustNotExist bool
+	mustBeFile   bool
+	mustBeDir    bool
+	readable     bool
+	writable     bool
+	glob         bool
+	extensions   []string
+}
+
+func (o pathOptions) completionHint() CompletionHint {
+	if o.mustBeDir {
+		return CompletionHintDir
+	}
+	return CompletionHintFile
+}
+
+func (o pathOptions) constraints() []string {
+	constraints := []string{}
+	switch {
+	case o.mustBeFile:
+		constraints = append(constraints, "existing file")
+	case o.mustBeDir:
+		constraints = append(constraints, "existing directory")
+	case o.mustExist:
+		constraints = append(constraints, "must exist")
+	case o.mustNotExist:
+		constraints = append(constraints, "must not exist")
+	}
+	if o.readable {
+		constraints = append(constraints, "readable")
+	}
+	if o.writable {
+		constraints = append(constraints, "writable")
+	}
+	if len(o.extensions) > 0 {
+		constraints = append(constraints, "extensions: "+strings.Join(o.extensions, ", "))
+	}
+	if o.glob {
+		constraints = append(constraints, "glob patterns")
+	}
+	return constraints
+}
+
+func (o pathOptions) parser() valueParser {
+	return func(ctx *Context, value string) (interface{}, error) {
+		paths, err := o.resolve(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) != 1 {
+			return nil, fmt.Errorf("pattern %q matched %d paths, expected exactly one", value, len(paths))
+		}
+		return paths[0], nil
+	}
+}
+
+func (o pathOptions) expander() valueExpander {
+	return func(ctx *Context, value string) ([]interface{}, error) {
+		paths, err := o.resolve(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]interface{}, len(paths))
+		for i, path := range paths {
+			values[i] = path
+		}
+		return values, nil
+	}
+}
+
+func (o pathOptions) resolve(ctx *Context, value string) ([]string, error) {
+	if value == "" {
+		return nil, fmt.Errorf("path must not be empty")
+	}
+
+	path := ctx.ResolvePath(value)
+	paths := []string{path}
+	if o.glob && hasGlobMeta(value) {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %v", value, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("pattern %q matched no paths", value)
+		}
+		paths = matches
+	}
+
+	for _, p := range paths {
+		if err := o.check(p); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+func (o pathOptions) check(path string) error {
+	if len(o.extensions) > 0 && !slices.Contains(o.extensions, strings.ToLower(filepath.Ext(path))) {
+		return fmt.Errorf("%s must have one of the extensions: %s", path, strings.Join(o.extensions, ", "))
+	}
+
+	info, err := os.Stat(path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("cannot access %s: %v", path, err)
+	}
+
+	if o.mustNotExist && exists {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if (o.mustExist || o.mustBeFile || o.mustBeDir || o.readable) && !exists {
+		return fmt.Errorf("%s does not exist", path)
+	}
+	if o.mustBeFile && !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a file", path)
+	}
+	if o.mustBeDir && !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+	if o.readable {
+		if err := checkReadable(path, info); err != nil {
+			return err
+		}
+	}
+	if o.writable {
+		if err := checkWritable(path, info, exists); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkReadable(path string, info os.FileInfo) error {
+	if info.IsDir() {
+		if _, err := os.ReadDir(path); err != nil {
+			return fmt.Errorf("%s is not readable: %v", path, err)
+		}
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%s is not readable: %v", path, err)
+	}
+	return file.Close()
+}
+
+func checkWritable(path string, info os.FileInfo, exists bool) error {
+	dir := path
+	if exists && !info.IsDir() {
+		file, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %v", path, err)
+		}
+		return file.Close()
+	}
+	if !exists {
+		dir = filepath.Dir(path)
+	}
+	probe, err := os.CreateTemp(dir, ".gear-probe-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %v", path, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+func normalizeExtensions(extensions []string) []string {
+	normalized := make([]string, len(extensions))
+	for i, ext := range extensions {
+		ext = strings.ToLower(ext)
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		normalized[i] = ext
+	}
+	return normalized
+}
+
+type pathArg struct {
+	typedArg[string]
+	options pathOptions
+}
+
+func NewPathArg(label string, description string) pathArg {
+	a := pathArg{
+		typedArg: typedArg[string]{
+			arg: NewArg(label, description, ValueTypePath, validateString),
+		},
+	}
+	return a.apply()
+}
+
+func (a pathArg) apply() pathArg {
+	a.arg.parser = a.options.parser()
+	a.arg.expander = a.options.expander()
+	a.arg.completion = a.options.completionHint()
+	a.arg.constraints = a.options.constraints()
+	return a
+}
+
+func (a pathArg) MustExist() pathArg {
+	a.options.mustExist = true
+	return a.apply()
+}
+
+func (a pathArg) MustNotExist() pathArg {
+	a.options.mustNotExist = true
+	return a.apply()
+}
+
+func (a pathArg) MustBeFile() pathArg {
+	a.options.mustBeFile = true
+	return a.apply()
+}
+
+func (a pathArg) MustBeDir() pathArg {
+	a.options.mustBeDir = true
+	return a.apply()
+}
+
+func (a pathArg) Readable() pathArg {
+	a.options.readable = true
+	return a.apply()
+}
+
+func (a pathArg) Writable() pathArg {
+	a.options.writable = true
+	return a.apply()
+}
+
+func (a pathArg) Glob() pathArg {
+	a.options.glob = true
+	return a.apply()
+}
+
+func (a pathArg) Extensions(extensions ...string) pathArg {
+	a.options.extensions = append(slices.Clone(a.options.extensions), normalizeExtensions(extensions)...)
+	return a.apply()
+}
+
+func (a pathArg) ExtendValidators(validators ...func(string) error) pathArg {
+	a.typedArg = a.typedArg.ExtendValidators(validators...)
+	return a
+}
+
+func (a pathArg) ExtendVariadicValidators(validators ...func([]string) error) pathArg {
+	a.typedArg = a.typedArg.ExtendVariadicValidators(validators...)
+	return a
+}
+
+func (a pathArg) Completer(completer completer) pathArg {
+	a.typedArg = a.typedArg.Completer(completer)
+	return a
+}
+
+func (a pathArg) AsOptional() pathArg {
+	a.typedArg = a.typedArg.AsOptional()
+	return a
+}
+
+func (a pathArg) AsVariadic() pathArg {
+	a.typedArg = a.typedArg.AsVariadic()
+	return a
+}
+
+type pathFlag struct {
+	typedFlag[string]
+	options pathOptions
+}
+
+func NewPathFlag(name string, shorthand string, description string, defaultValue string) pathFlag {
+	f := pathFlag{
+		typedFlag: typedFlag[string]{
+			flag: NewFlag(name, shorthand, description, ValueTypePath, defaultValue, validateString),
+		},
+	}
+	return f.apply()
+}
+
+func (f pathFlag) apply() pathFlag {
+	f.flag.parser = f.options.parser()
+	f.flag.completion = f.options.completionHint()
+	f.flag.constraints = f.options.constraints()
+	return f
+}
+
+func (f pathFlag) MustExist() pathFlag {
+	f.options.mustExist = true
+	return f.apply()
+}
+
+func (f pathFlag) MustNotExist() pathFlag {
+	f.options.mustNotExist = true
+	return f.apply()
+}
+
+func (f pathFlag) MustBeFile() pathFlag {
+	f.options.mustBeFile = true
+	return f.apply()
+}
+
+func (f pathFlag) MustBeDir() pathFlag {
+	f.options.mustBeDir = true
+	return f.apply()
+}
+
+func (f pathFlag) Readable() pathFlag {
+	f.options.readable = true
+	return f.apply()
+}
+
+func (f pathFlag) Writable() pathFlag {
+	f.options.writable = true
+	return f.apply()
+}
+
+func (f pathFlag) Glob() pathFlag {
+	f.options.glob = true
+	return f.apply()
+}
+
+func (f pathFlag) Extensions(extensions ...string) pathFlag {
+	f.options.extensions = append(slices.Clone(f.options.extensions), normalizeExtensions(extensions)...)
+	return f.apply()
+}
+
+func (f pathFlag) ExtendValidators(validators ...func(string) error) pathFlag {
+	f.typedFlag = f.typedFlag.ExtendValidators(validators...)
+	return f
+}
+
+func (f pathFlag) Completer(completer completer) pathFlag {
+	f.typedFlag = f.typedFlag.Completer(completer)
+	return f
+}
+
+func (f pathFlag) Hidden() pathFlag {
+	f.typedFlag = f.typedFlag.Hidden()
+	return f
+}
+
+func (f pathFlag) Deprecated(message string) pathFlag {
+	f.typedFlag = f.typedFlag.Deprecated(message)
+	return f
+}
+
+func (f pathFlag) FailAfter(version string) pathFlag {
+	f.typedFlag = f.typedFlag.FailAfter(version)
+	return f
+}
+
+func (f pathFlag) DeprecatedAlias(names ...string) pathFlag {
+	f.typedFlag = f.typedFlag.DeprecatedAlias(names...)
+	return f
+}
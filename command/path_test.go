@@ -0,0 +1,48 @@
+package command_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestPathFlagDefaultIsChecked(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "custom"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	report := command.NewExecutableCommand("report", "Write a report").
+		Flags(command.NewPathFlag("out", "o", "Report directory", "reports").MustBeDir()).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			got = args.FlagString("out")
+			return nil
+		})
+	root := command.NewRootCommand("app", "Test application").WorkingDir(dir).AddChild(report)
+
+	err := root.Run([]string{"report"})
+	if err == nil || !strings.Contains(err.Error(), "invalid default value for flag 'out'") {
+		t.Errorf("Run with a missing default directory returned %v", err)
+	}
+
+	if err := root.Run([]string{"report", "-o", "custom"}); err != nil {
+		t.Fatalf("Run with an explicit directory returned %v", err)
+	}
+	if want := filepath.Join(dir, "custom"); got != want {
+		t.Errorf("out = %q, want %q", got, want)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "reports"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := root.Run([]string{"report"}); err != nil {
+		t.Fatalf("Run with an existing default directory returned %v", err)
+	}
+	if want := filepath.Join(dir, "reports"); got != want {
+		t.Errorf("out = %q, want %q", got, want)
+	}
+}
@@ -1,7 +1,12 @@
 package command
 
 import (
+	stdcontext "context"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
+	"text/template"
 )
 
 type RootCommand struct {
@@ -9,18 +14,31 @@ type RootCommand struct {
 
 	children    map[string]Command
 	globalFlags []Flag
+	workingDir  string
+	stdin       io.Reader
+	stdout      io.Writer
+	stderr      io.Writer
+
+	aggregateErrors bool
+	version         string
+	versionEnabled  bool
+	versionTemplate *template.Template
+
+	validateOnce sync.Once
+	validateErr  error
 }
 
 func NewRootCommand(label, description string) *RootCommand {
-	return &RootCommand{
+	c := &RootCommand{
 		baseCommand: newBaseCommand(label, description),
 		children:    make(map[string]Command),
 		globalFlags: []Flag{},
 	}
+	return c.AddChild(newHelpCommand(c))
 }
 
 func (c *RootCommand) AddChild(command Command) *RootCommand {
-	c.children[command.Label()] = command
+	addChild(c, c.children, command)
 	return c
 }
 
@@ -29,31 +47,114 @@ func (c *RootCommand) GlobalFlags(flags ...Flag) *RootCommand {
 	return c
 }
 
+func (c *RootCommand) WorkingDir(dir string) *RootCommand {
+	c.workingDir = dir
+	return c
+}
+
+func (c *RootCommand) Stdin(stdin io.Reader) *RootCommand {
+	c.stdin = stdin
+	return c
+}
+
+func (c *RootCommand) Stdout(stdout io.Writer) *RootCommand {
+	c.stdout = stdout
+	return c
+}
+
+func (c *RootCommand) Stderr(stderr io.Writer) *RootCommand {
+	c.stderr = stderr
+	return c
+}
+
+func (c *RootCommand) AggregateErrors() *RootCommand {
+	c.aggregateErrors = true
+	return c
+}
+
+func (c *RootCommand) HelpTemplate(text string) *RootCommand {
+	c.setHelpTemplate(text)
+	return c
+}
+
+func (c *RootCommand) UsageTemplate(text string) *RootCommand {
+	c.setUsageTemplate(text)
+	return c
+}
+
 func (c *RootCommand) Run(args []string) error {
-	return c.run(args)
+	c.validateOnce.Do(func() {
+		c.validateErr = c.Validate()
+	})
+	if c.validateErr != nil {
+		return c.validateErr
+	}
+	return c.run(c.newRunContext(stdcontext.Background()), args, nil)
+}
+
+func (c *RootCommand) newRunContext(parent stdcontext.Context) *Context {
+	ctx := newContext(parent, c)
+	if c.workingDir != "" {
+		ctx.workingDir = ctx.ResolvePath(c.workingDir)
+	}
+	if c.stdin != nil {
+		ctx.stdin = c.stdin
+	}
+	if c.stdout != nil {
+		ctx.stdout = c.stdout
+	}
+	if c.stderr != nil {
+		ctx.stderr = c.stderr
+	}
+	ctx.aggregateErrors = c.aggregateErrors
+	return ctx
+}
+
+func (c *RootCommand) run(ctx *Context, args []string, inherited []Flag) error {
+	err := c.dispatch(ctx, args, inherited)
+	if len(ctx.leadingErrors) == 0 || errors.Is(err, ErrHelp) {
+		return err
+	}
+	errs := ctx.takeLeadingErrors()
+	errs.Append(err)
+	return errs
 }
 
-func (c *RootCommand) run(args []string) error {
+func (c *RootCommand) dispatch(ctx *Context, args []string, inherited []Flag) error {
+	if len(args) > 0 && args[0] == completeCommandName {
+		return c.runComplete(ctx, args[1:])
+	}
+
+	flags := mergeFlags(c.globalFlags, inherited)
+	args, err := parseLeadingFlags(ctx, flags, args)
+	if err != nil {
+		return err
+	}
 	if len(args) < 1 {
-		c.PrintHelp()
+		if len(ctx.leadingErrors) > 0 {
+			return nil
+		}
+		newHelpPrinter(ctx.Stdout()).PrintHelp(c)
 		return nil
 	}
 
 	commandName := args[0]
+	if isHelpFlag(commandName) {
+		return requestHelp(ctx, c)
+	}
+	if isVersionFlag(commandName) && c.versionEnabled {
+		return c.printVersion(ctx)
+	}
+
 	childCommand, exists := c.children[commandName]
 	if !exists {
 		return fmt.Errorf("unknown command: %s", commandName)
 	}
 
-	childCommand.inheritGlobalFlags(c.globalFlags)
-	return childCommand.run(args[1:])
+	return childCommand.run(ctx, args[1:], flags)
 }
 
 func (c *RootCommand) PrintHelp() {
-	printer := newHelpPrinter()
-	printer.PrintRootCommandHelp(c)
-}
-
-func (c *RootCommand) inheritGlobalFlags(flags []Flag) {
-	c.globalFlags = append(c.globalFlags, flags...)
+	printer := newHelpPrinter(helpWriter(c))
+	printer.PrintHelp(c)
 }
@@ -0,0 +1,138 @@
+package command_test
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) record(format string, args ...interface{}) {
+	r.calls = append(r.calls, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) last() string {
+	if len(r.calls) == 0 {
+		return ""
+	}
+	return r.calls[len(r.calls)-1]
+}
+
+func newTestRoot(r *recorder) *command.RootCommand {
+	deploy := command.NewExecutableCommand("deploy", "Deploy a service").
+		Args(command.NewStringArg("target", "Deployment target")).
+		Flags(command.NewBoolFlag("force", "f", "Skip confirmation", false)).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			r.record("deploy %s force=%t verbose=%t env=%s", args.String("target"), args.FlagBool("force"), args.FlagBool("verbose"), args.FlagString("env"))
+			return nil
+		})
+
+	status := command.NewExecutableCommand("status", "Show cluster status").
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			r.record("status verbose=%t", args.FlagBool("verbose"))
+			return nil
+		})
+	scale := command.NewExecutableCommand("scale", "Scale the cluster").
+		Args(command.NewIntArg("replicas", "Replica count")).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			r.record("scale %d verbose=%t", args.Int("replicas"), args.FlagBool("verbose"))
+			return nil
+		})
+	cluster := command.NewSubcommand("cluster", "Manage the cluster").
+		AddChild(status).
+		AddChild(scale).
+		Default("status")
+
+	add := command.NewExecutableCommand("add", "Add a remote").
+		Args(command.NewStringArg("name", "Remote name"), command.NewStringArg("url", "Remote URL")).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			r.record("remote add %s %s verbose=%t", args.String("name"), args.String("url"), args.FlagBool("verbose"))
+			return nil
+		})
+	remote := command.NewExecutableCommand("remote", "List remotes").
+		Args(command.NewStringArg("filter", "Name filter").AsOptional()).
+		Flags(command.NewBoolFlag("long", "l", "Show URLs", false)).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			r.record("remote list %q long=%t", args.String("filter"), args.FlagBool("long"))
+			return nil
+		}).
+		AddChild(add)
+
+	return command.NewRootCommand("app", "Test application").
+		GlobalFlags(
+			command.NewBoolFlag("verbose", "v", "Verbose output", false),
+			command.NewStringFlag("env", "e", "Target environment", "dev"),
+		).
+		AddChild(deploy).
+		AddChild(cluster).
+		AddChild(remote).
+		Stdout(io.Discard).
+		Stderr(io.Discard)
+}
+
+func runAll(t *testing.T, root *command.RootCommand, r *recorder, tests []struct {
+	args []string
+	want string
+}) {
+	t.Helper()
+	for _, tt := range tests {
+		if err := root.Run(tt.args); err != nil {
+			t.Errorf("Run(%q) returned error: %v", tt.args, err)
+			continue
+		}
+		if got := r.last(); got != tt.want {
+			t.Errorf("Run(%q) recorded %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunTwiceDoesNotLeakState(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r)
+
+	runAll(t, root, r, []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--verbose", "-e", "prod", "deploy", "web", "--force"}, "deploy web force=true verbose=true env=prod"},
+		{[]string{"deploy", "api"}, "deploy api force=false verbose=false env=dev"},
+	})
+}
+
+func TestRunConcurrently(t *testing.T) {
+	echo := command.NewExecutableCommand("echo", "Echo a number").
+		Args(command.NewIntArg("value", "Value to echo")).
+		Flags(command.NewIntFlag("expect", "x", "Expected value", 0)).
+		Handler(func(ctx *command.Context, args command.ValidatedArgs) error {
+			if args.Int("value") != args.FlagInt("expect") {
+				return fmt.Errorf("got value %d with --expect %d", args.Int("value"), args.FlagInt("expect"))
+			}
+			return nil
+		})
+	root := command.NewRootCommand("app", "Test application").AddChild(echo)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			n := strconv.Itoa(i)
+			if err := root.Run([]string{"echo", "--expect", n, n}); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -2,13 +2,15 @@ package command
 
 import (
 	"fmt"
+	"strings"
 )
 
 type Subcommand struct {
 	*baseCommand
 
-	children    map[string]Command
-	globalFlags []Flag
+	children     map[string]Command
+	defaultChild string
+	fallThrough  bool
 }
 
 func NewSubcommand(label, description string) *Subcommand {
@@ -19,31 +21,110 @@ func NewSubcommand(label, description string) *Subcommand {
 }
 
 func (c *Subcommand) AddChild(command Command) *Subcommand {
-	c.children[command.Label()] = command
+	addChild(c, c.children, command)
 	return c
 }
 
-func (c *Subcommand) run(args []string) error {
+func (c *Subcommand) Default(label string) *Subcommand {
+	c.defaultChild = label
+	return c
+}
+
+func (c *Subcommand) FallThroughToDefault() *Subcommand {
+	c.fallThrough = true
+	return c
+}
+
+func (c *Subcommand) Hidden() *Subcommand {
+	c.hidden = true
+	return c
+}
+
+func (c *Subcommand) Deprecated(message string) *Subcommand {
+	c.deprecation = c.deprecation.deprecate(message)
+	return c
+}
+
+func (c *Subcommand) FailAfter(version string) *Subcommand {
+	c.deprecation = c.deprecation.failAfterVersion(version)
+	return c
+}
+
+func (c *Subcommand) HelpTemplate(text string) *Subcommand {
+	c.setHelpTemplate(text)
+	return c
+}
+
+func (c *Subcommand) UsageTemplate(text string) *Subcommand {
+	c.setUsageTemplate(text)
+	return c
+}
+
+func (c *Subcommand) run(ctx *Context, args []string, inherited []Flag) error {
+	if err := checkCommandDeprecation(ctx, c); err != nil {
+		return err
+	}
+	if defaultCommand := c.defaultCommand(); defaultCommand != nil && c.runsDefault(args, inherited) {
+		return defaultCommand.run(ctx, args, inherited)
+	}
+
+	args, err := parseLeadingFlags(ctx, inherited, args)
+	if err != nil {
+		return err
+	}
 	if len(args) < 1 {
-		c.PrintHelp()
+		if len(ctx.leadingErrors) > 0 {
+			return nil
+		}
+		newHelpPrinter(ctx.Stdout()).PrintHelp(c)
 		return nil
 	}
 
 	commandName := args[0]
+	if isHelpFlag(commandName) {
+		return requestHelp(ctx, c)
+	}
 	childCommand, exists := c.children[commandName]
 	if !exists {
 		return fmt.Errorf("unknown subcommand: %s", commandName)
 	}
 
-	childCommand.inheritGlobalFlags(c.globalFlags)
-	return childCommand.run(args[1:])
+	return childCommand.run(ctx, args[1:], inherited)
 }
 
-func (c *Subcommand) PrintHelp() {
-	printer := newHelpPrinter()
-	printer.PrintSubcommandHelp(c)
+func (c *Subcommand) defaultCommand() Command {
+	if c.defaultChild == "" {
+		return nil
+	}
+	return c.children[c.defaultChild]
+}
+
+func (c *Subcommand) runsDefault(args []string, inherited []Flag) bool {
+	flags := inherited
+	if executable, ok := c.defaultCommand().(*executableCommand); ok {
+		flags = mergeFlags(executable.flags, inherited)
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isHelpFlag(arg) {
+			return false
+		}
+		if arg == "--" {
+			return c.fallThrough
+		}
+		if !strings.HasPrefix(arg, "-") || len(arg) < 2 {
+			_, isChild := c.children[arg]
+			return !isChild && c.fallThrough
+		}
+		if f, hasValue := lookupFlagToken(flags, arg); f != nil && !hasValue && f.Expected() != ValueTypeBool {
+			i++
+		}
+	}
+	return true
 }
 
-func (c *Subcommand) inheritGlobalFlags(flags []Flag) {
-	c.globalFlags = append(c.globalFlags, flags...)
+func (c *Subcommand) PrintHelp() {
+	printer := newHelpPrinter(helpWriter(c))
+	printer.PrintHelp(c)
 }
@@ -0,0 +1,23 @@
+package command_test
+
+import (
+	"testing"
+)
+
+func TestDefaultChild(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r)
+
+	runAll(t, root, r, []struct {
+		args []string
+		want string
+	}{
+		{[]string{"cluster"}, "status verbose=false"},
+		{[]string{"cluster", "-v"}, "status verbose=true"},
+		{[]string{"cluster", "scale", "2"}, "scale 2 verbose=false"},
+	})
+
+	if err := root.Run([]string{"cluster", "unknown"}); err == nil {
+		t.Error("expected an error for an unknown subcommand without FallThroughToDefault")
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package command
+
+func terminalWidth(fd uintptr) int {
+	return 0
+}
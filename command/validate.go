@@ -0,0 +1,111 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func (c *RootCommand) Validate() error {
+	var errs []error
+	validateCommand(c, []string{c.Label()}, nil, &errs)
+	return errors.Join(errs...)
+}
+
+func validateCommand(cmd Command, path []string, inherited []Flag, errs *[]error) {
+	report := func(format string, args ...interface{}) {
+		*errs = append(*errs, fmt.Errorf("%s: %s", strings.Join(path, " "), fmt.Sprintf(format, args...)))
+	}
+
+	var local []Flag
+	switch c := cmd.(type) {
+	case *RootCommand:
+		local = c.globalFlags
+		if c.versionEnabled {
+			for _, f := range local {
+				if f.Name() == "version" || f.Shorthand() == "V" {
+					report("flag %s is reserved for --version", flagNames(f))
+				}
+			}
+		}
+	case *Subcommand:
+		if _, ok := c.children[c.defaultChild]; c.defaultChild != "" && !ok {
+			report("default child %q does not exist", c.defaultChild)
+		}
+		if c.fallThrough && c.defaultChild == "" {
+			report("fall-through requires a default child")
+		}
+	case *executableCommand:
+		local = c.flags
+		validateArgs(c.args, report)
+	}
+
+	for i, f := range local {
+		if f.Name() == "help" || f.Shorthand() == "h" {
+			report("flag %s is reserved for --help", flagNames(f))
+		}
+		for _, other := range local[:i] {
+			if name := sharedFlagName(f, other); name != "" {
+				report("flag --%s is defined more than once", name)
+			}
+			if f.Shorthand() != "" && f.Shorthand() == other.Shorthand() {
+				report("shorthand -%s is used by both --%s and --%s", f.Shorthand(), other.Name(), f.Name())
+			}
+		}
+		for _, parent := range inherited {
+			if name := sharedFlagName(f, parent); name != "" {
+				report("flag --%s shadows an inherited flag", name)
+			} else if f.Shorthand() != "" && f.Shorthand() == parent.Shorthand() {
+				report("shorthand -%s of --%s conflicts with inherited --%s", f.Shorthand(), f.Name(), parent.Name())
+			}
+		}
+	}
+
+	for _, label := range cmd.base().duplicateChildren {
+		report("child command %q is added more than once", label)
+	}
+
+	flags := childFlags(cmd, local, inherited)
+	children := commandChildren(cmd)
+	for _, label := range sortedChildLabels(children) {
+		validateCommand(children[label], append(path[:len(path):len(path)], label), flags, errs)
+	}
+}
+
+func validateArgs(args []Arg, report func(format string, args ...interface{})) {
+	variadic := ""
+	optional := ""
+	for i, arg := range args {
+		if arg.IsVariadic() {
+			if variadic != "" {
+				report("arguments %q and %q are both variadic", variadic, arg.Label())
+			} else if i != len(args)-1 {
+				report("variadic argument %q must be the last argument", arg.Label())
+			}
+			variadic = arg.Label()
+		}
+		if arg.IsOptional() {
+			if optional == "" {
+				optional = arg.Label()
+			}
+		} else if optional != "" {
+			report("required argument %q follows optional argument %q", arg.Label(), optional)
+		}
+	}
+}
+
+func sharedFlagName(a Flag, b Flag) string {
+	for _, name := range append([]string{a.Name()}, a.aliasNames()...) {
+		if flagHasName(b, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+func flagNames(f Flag) string {
+	if f.Shorthand() == "" {
+		return "--" + f.Name()
+	}
+	return fmt.Sprintf("--%s (-%s)", f.Name(), f.Shorthand())
+}
@@ -0,0 +1,158 @@
+package command_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestValidate(t *testing.T) {
+	run := func(flags ...command.Flag) *command.RootCommand {
+		return command.NewRootCommand("app", "Test application").
+			AddChild(command.NewExecutableCommand("run", "Run").Flags(flags...))
+	}
+	withArgs := func(args ...command.Arg) *command.RootCommand {
+		return command.NewRootCommand("app", "Test application").
+			AddChild(command.NewExecutableCommand("run", "Run").Args(args...))
+	}
+	withGlobal := func(global command.Flag, local command.Flag) *command.RootCommand {
+		return command.NewRootCommand("app", "Test application").
+			GlobalFlags(global).
+			AddChild(command.NewExecutableCommand("run", "Run").Flags(local))
+	}
+
+	tests := []struct {
+		name string
+		root *command.RootCommand
+		want string
+	}{
+		{
+			name: "valid tree",
+			root: newTestRoot(&recorder{}).Version("1.0.0"),
+		},
+		{
+			name: "duplicate flag name",
+			root: run(command.NewBoolFlag("force", "f", "", false), command.NewStringFlag("force", "", "", "")),
+			want: "app run: flag --force is defined more than once",
+		},
+		{
+			name: "duplicate deprecated alias",
+			root: run(command.NewBoolFlag("force", "f", "", false), command.NewBoolFlag("yes", "y", "", false).DeprecatedAlias("force")),
+			want: "app run: flag --force is defined more than once",
+		},
+		{
+			name: "duplicate shorthand",
+			root: run(command.NewBoolFlag("force", "f", "", false), command.NewBoolFlag("fast", "f", "", false)),
+			want: "app run: shorthand -f is used by both --force and --fast",
+		},
+		{
+			name: "shadowed global flag",
+			root: withGlobal(command.NewBoolFlag("debug", "d", "", false), command.NewBoolFlag("debug", "", "", false)),
+			want: "app run: flag --debug shadows an inherited flag",
+		},
+		{
+			name: "shadowed hidden global flag",
+			root: withGlobal(command.NewBoolFlag("debug", "d", "", false).Hidden(), command.NewBoolFlag("debug", "d", "", false)),
+			want: "app run: flag --debug shadows an inherited flag",
+		},
+		{
+			name: "shorthand conflicts with global flag",
+			root: withGlobal(command.NewBoolFlag("debug", "d", "", false), command.NewBoolFlag("dry-run", "d", "", false)),
+			want: "app run: shorthand -d of --dry-run conflicts with inherited --debug",
+		},
+		{
+			name: "reserved help shorthand",
+			root: run(command.NewStringFlag("host", "h", "", "")),
+			want: "app run: flag --host (-h) is reserved for --help",
+		},
+		{
+			name: "reserved help name",
+			root: run(command.NewBoolFlag("help", "", "", false)),
+			want: "app run: flag --help is reserved for --help",
+		},
+		{
+			name: "reserved version shorthand",
+			root: command.NewRootCommand("app", "Test application").
+				GlobalFlags(command.NewBoolFlag("verbose", "V", "", false)).
+				Version("1.0.0"),
+			want: "app: flag --verbose (-V) is reserved for --version",
+		},
+		{
+			name: "version shorthand is free without Version",
+			root: command.NewRootCommand("app", "Test application").
+				GlobalFlags(command.NewBoolFlag("verbose", "V", "", false)),
+		},
+		{
+			name: "variadic before the last argument",
+			root: withArgs(command.NewStringArg("files", "").AsVariadic(), command.NewStringArg("dest", "")),
+			want: `app run: variadic argument "files" must be the last argument`,
+		},
+		{
+			name: "two variadic arguments",
+			root: withArgs(command.NewStringArg("a", "").AsVariadic(), command.NewStringArg("b", "").AsVariadic()),
+			want: `app run: arguments "a" and "b" are both variadic`,
+		},
+		{
+			name: "required after optional",
+			root: withArgs(command.NewStringArg("a", "").AsOptional(), command.NewStringArg("b", "")),
+			want: `app run: required argument "b" follows optional argument "a"`,
+		},
+		{
+			name: "optional variadic after optional",
+			root: withArgs(command.NewStringArg("a", "").AsOptional(), command.NewStringArg("b", "").AsOptional().AsVariadic()),
+		},
+		{
+			name: "duplicate child",
+			root: command.NewRootCommand("app", "Test application").
+				AddChild(command.NewExecutableCommand("run", "Run")).
+				AddChild(command.NewExecutableCommand("run", "Run again")),
+			want: `app: child command "run" is added more than once`,
+		},
+		{
+			name: "missing default child",
+			root: command.NewRootCommand("app", "Test application").
+				AddChild(command.NewSubcommand("db", "Database").
+					AddChild(command.NewExecutableCommand("migrate", "Migrate")).
+					Default("status")),
+			want: `app db: default child "status" does not exist`,
+		},
+		{
+			name: "fall-through without default child",
+			root: command.NewRootCommand("app", "Test application").
+				AddChild(command.NewSubcommand("db", "Database").
+					AddChild(command.NewExecutableCommand("migrate", "Migrate")).
+					FallThroughToDefault()),
+			want: "app db: fall-through requires a default child",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.root.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate returned %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate returned %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunReportsInvalidTree(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r).AddChild(command.NewExecutableCommand("deploy", "Deploy again"))
+
+	for i := 0; i < 2; i++ {
+		err := root.Run([]string{"deploy", "web"})
+		if err == nil || !strings.Contains(err.Error(), `child command "deploy" is added more than once`) {
+			t.Errorf("Run %d returned %v, want the validation error", i+1, err)
+		}
+	}
+	if len(r.calls) != 0 {
+		t.Errorf("handlers ran on an invalid tree: %q", r.calls)
+	}
+}
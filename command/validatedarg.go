@@ -1,6 +1,10 @@
 package command
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
 
 type ValidatedArgs struct {
 	args     map[string]validatedArg
@@ -106,6 +110,40 @@ func (v *ValidatedArgs) GetBool(name string) (bool, error) {
 	return b, nil
 }
 
+func (v *ValidatedArgs) Reader(name string) io.Reader {
+	r, _ := v.GetReader(name)
+	return r
+}
+
+func (v *ValidatedArgs) GetReader(name string) (io.Reader, error) {
+	arg, ok := v.args[name]
+	if !ok {
+		return nil, fmt.Errorf("argument %s not found", name)
+	}
+	input, ok := arg.(*Input)
+	if !ok {
+		return nil, fmt.Errorf("argument %s is not an input", name)
+	}
+	return input, nil
+}
+
+func (v *ValidatedArgs) Bytes(name string) []byte {
+	b, _ := v.GetBytes(name)
+	return b
+}
+
+func (v *ValidatedArgs) GetBytes(name string) ([]byte, error) {
+	arg, ok := v.args[name]
+	if !ok {
+		return nil, fmt.Errorf("argument %s not found", name)
+	}
+	input, ok := arg.(*Input)
+	if !ok {
+		return nil, fmt.Errorf("argument %s is not an input", name)
+	}
+	return input.Bytes()
+}
+
 func (v *ValidatedArgs) GetFlag(name string) validatedArg {
 	return v.flags[name]
 }
@@ -183,6 +221,40 @@ func (v *ValidatedArgs) GetFlagBool(name string) (bool, error) {
 	return b, nil
 }
 
+func (v *ValidatedArgs) FlagReader(name string) io.Reader {
+	r, _ := v.GetFlagReader(name)
+	return r
+}
+
+func (v *ValidatedArgs) GetFlagReader(name string) (io.Reader, error) {
+	flag, ok := v.flags[name]
+	if !ok {
+		return nil, fmt.Errorf("flag %s not found", name)
+	}
+	input, ok := flag.(*Input)
+	if !ok {
+		return nil, fmt.Errorf("flag %s is not an input", name)
+	}
+	return input, nil
+}
+
+func (v *ValidatedArgs) FlagBytes(name string) []byte {
+	b, _ := v.GetFlagBytes(name)
+	return b
+}
+
+func (v *ValidatedArgs) GetFlagBytes(name string) ([]byte, error) {
+	flag, ok := v.flags[name]
+	if !ok {
+		return nil, fmt.Errorf("flag %s not found", name)
+	}
+	input, ok := flag.(*Input)
+	if !ok {
+		return nil, fmt.Errorf("flag %s is not an input", name)
+	}
+	return input.Bytes()
+}
+
 func (v *ValidatedArgs) GetVariadic(name string) []interface{} {
 	return v.variadic[name]
 }
@@ -275,3 +347,29 @@ func (v *ValidatedArgs) VariadicBools(name string) []bool {
 	bools, _ := v.GetVariadicBools(name)
 	return bools
 }
+
+func ArgValue[T any](v ValidatedArgs, name string) (T, error) {
+	var zero T
+	arg, ok := v.args[name]
+	if !ok {
+		return zero, fmt.Errorf("argument %s not found", name)
+	}
+	value, ok := arg.(T)
+	if !ok && arg != nil {
+		return zero, fmt.Errorf("argument %s is not a %s", name, reflect.TypeFor[T]())
+	}
+	return value, nil
+}
+
+func FlagValue[T any](v ValidatedArgs, name string) (T, error) {
+	var zero T
+	flag, ok := v.flags[name]
+	if !ok {
+		return zero, fmt.Errorf("flag %s not found", name)
+	}
+	value, ok := flag.(T)
+	if !ok && flag != nil {
+		return zero, fmt.Errorf("flag %s is not a %s", name, reflect.TypeFor[T]())
+	}
+	return value, nil
+}
@@ -0,0 +1,166 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ErrorKind int
+
+const (
+	ErrorKindValidation ErrorKind = iota
+	ErrorKindUnknownFlag
+	ErrorKindMissingValue
+	ErrorKindMissingArgument
+	ErrorKindTooManyArguments
+	ErrorKindInvalidValue
+	ErrorKindRemoved
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindUnknownFlag:
+		return "unknown flag"
+	case ErrorKindMissingValue:
+		return "missing value"
+	case ErrorKindMissingArgument:
+		return "missing argument"
+	case ErrorKindTooManyArguments:
+		return "too many arguments"
+	case ErrorKindInvalidValue:
+		return "invalid value"
+	case ErrorKindRemoved:
+		return "removed"
+	default:
+		return "validation"
+	}
+}
+
+type FieldError struct {
+	Field string
+	Kind  ErrorKind
+	Err   error
+
+	message string
+}
+
+func NewFieldError(field string, format string, args ...interface{}) *FieldError {
+	return &FieldError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+func newFieldError(kind ErrorKind, field string, err error, format string, args ...interface{}) *FieldError {
+	message := fmt.Sprintf(format, args...)
+	if err == nil {
+		err = errors.New(message)
+	}
+	return &FieldError{Field: field, Kind: kind, Err: err, message: message}
+}
+
+func (e *FieldError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type ValidationErrors []*FieldError
+
+func (e *ValidationErrors) Add(field string, format string, args ...interface{}) {
+	*e = append(*e, NewFieldError(field, format, args...))
+}
+
+func (e *ValidationErrors) Append(err error) {
+	if err == nil {
+		return
+	}
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		*e = append(*e, validationErrs...)
+		return
+	}
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		*e = append(*e, fieldErr)
+		return
+	}
+	*e = append(*e, &FieldError{Err: err})
+}
+
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e ValidationErrors) ByKind(kind ErrorKind) ValidationErrors {
+	matched := ValidationErrors{}
+	for _, err := range e {
+		if err.Kind == kind {
+			matched = append(matched, err)
+		}
+	}
+	return matched
+}
+
+func (e ValidationErrors) Fields() []string {
+	fields := []string{}
+	for _, err := range e {
+		if err.Field != "" {
+			fields = append(fields, err.Field)
+		}
+	}
+	return fields
+}
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d validation errors:", len(e))
+	for _, err := range e {
+		fmt.Fprintf(&b, "\n  - %s", err.Error())
+	}
+	return b.String()
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+type errorCollector struct {
+	aggregate bool
+	errs      ValidationErrors
+}
+
+func newErrorCollector(ctx *Context) *errorCollector {
+	return &errorCollector{aggregate: ctx.aggregateErrors}
+}
+
+func (c *errorCollector) collect(err error) (stop bool) {
+	c.errs.Append(err)
+	return !c.aggregate
+}
+
+func (c *errorCollector) err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	if !c.aggregate {
+		return c.errs[0]
+	}
+	return c.errs
+}
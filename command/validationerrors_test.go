@@ -0,0 +1,67 @@
+package command_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestAggregateErrors(t *testing.T) {
+	r := &recorder{}
+	root := newTestRoot(r).AggregateErrors()
+
+	tests := []struct {
+		args  []string
+		kinds []command.ErrorKind
+	}{
+		{
+			[]string{"deploy", "--bogus"},
+			[]command.ErrorKind{command.ErrorKindUnknownFlag, command.ErrorKindMissingArgument},
+		},
+		{
+			[]string{"cluster", "scale", "many", "--nope"},
+			[]command.ErrorKind{command.ErrorKindUnknownFlag, command.ErrorKindInvalidValue},
+		},
+	}
+	for _, tt := range tests {
+		err := root.Run(tt.args)
+		var errs command.ValidationErrors
+		if !errors.As(err, &errs) {
+			t.Errorf("Run(%q) returned %v, want ValidationErrors", tt.args, err)
+			continue
+		}
+		if len(errs) != len(tt.kinds) {
+			t.Errorf("Run(%q) returned %d errors, want %d: %v", tt.args, len(errs), len(tt.kinds), err)
+			continue
+		}
+		for i, kind := range tt.kinds {
+			if errs[i].Kind != kind {
+				t.Errorf("Run(%q) error %d has kind %v, want %v", tt.args, i, errs[i].Kind, kind)
+			}
+		}
+	}
+	if len(r.calls) != 0 {
+		t.Errorf("handlers ran despite errors: %q", r.calls)
+	}
+}
+
+func TestAggregateMissingVariadic(t *testing.T) {
+	files := command.NewExecutableCommand("files", "List files").
+		Args(command.NewStringArg("names", "File names").AsVariadic()).
+		Flags(command.NewIntFlag("depth", "d", "Depth", 0))
+	root := command.NewRootCommand("app", "Test application").
+		AddChild(files).
+		AggregateErrors().
+		Stdout(io.Discard)
+
+	err := root.Run([]string{"files", "--depth", "deep"})
+	var errs command.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Run returned %v, want two errors", err)
+	}
+	if missing := errs.ByKind(command.ErrorKindMissingArgument); len(missing) != 1 || missing[0].Field != "names" {
+		t.Errorf("expected a missing argument error for names, got %v", err)
+	}
+}
@@ -12,10 +12,26 @@ const (
 	ValueTypeInt    ValueType = "int"
 	ValueTypeFloat  ValueType = "float"
 	ValueTypeBool   ValueType = "bool"
+	ValueTypePath   ValueType = "path"
+	ValueTypeInput  ValueType = "input"
+	ValueTypeJSON   ValueType = "json"
+	ValueTypeEnum   ValueType = "enum"
+)
+
+type CompletionHint int
+
+const (
+	CompletionHintNone CompletionHint = iota
+	CompletionHintFile
+	CompletionHintDir
 )
 
 type validator func(value interface{}) error
 
+type valueParser func(ctx *Context, value string) (interface{}, error)
+
+type valueExpander func(ctx *Context, value string) ([]interface{}, error)
+
 func toValidator[T any](typedValidator func(T) error) validator {
 	return func(value interface{}) error {
 		typedValue, ok := value.(T)
@@ -26,9 +42,27 @@ func toValidator[T any](typedValidator func(T) error) validator {
 	}
 }
 
+func toVariadicValidator[T any](typedValidator func([]T) error) validator {
+	return func(value interface{}) error {
+		values, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected variadic values, got %T", value)
+		}
+		typedValues := make([]T, len(values))
+		for i, v := range values {
+			typedValue, ok := v.(T)
+			if !ok {
+				return fmt.Errorf("expected %T at position %d, got %T", *new(T), i, v)
+			}
+			typedValues[i] = typedValue
+		}
+		return typedValidator(typedValues)
+	}
+}
+
 func parseValue(value string, expectedType ValueType) (interface{}, error) {
 	switch expectedType {
-	case ValueTypeString:
+	case ValueTypeString, ValueTypePath, ValueTypeEnum:
 		return value, nil
 	case ValueTypeInt:
 		return strconv.Atoi(value)
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"text/template"
+)
+
+const versionCommandName = "version"
+
+type VersionInfo struct {
+	Name         string              `json:"name"`
+	Version      string              `json:"version"`
+	Commit       string              `json:"commit,omitempty"`
+	BuildDate    string              `json:"buildDate,omitempty"`
+	Modified     bool                `json:"modified,omitempty"`
+	GoVersion    string              `json:"goVersion"`
+	Platform     string              `json:"platform"`
+	Dependencies []VersionDependency `json:"dependencies,omitempty"`
+}
+
+type VersionDependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+func (c *RootCommand) Version(version string) *RootCommand {
+	c.version = version
+	if c.versionEnabled {
+		return c
+	}
+	c.versionEnabled = true
+
+	command := NewExecutableCommand(versionCommandName, "Print version information").
+		Flags(NewEnumFlag("output", "o", "Output format", "text", "text", "json")).
+		Handler(func(ctx *Context, args ValidatedArgs) error {
+			if args.FlagString("output") == "json" {
+				return c.printVersionJSON(ctx)
+			}
+			return c.printVersion(ctx)
+		})
+	return c.AddChild(command)
+}
+
+func (c *RootCommand) VersionTemplate(text string) *RootCommand {
+	c.versionTemplate = template.Must(newHelpTemplate("version", text))
+	return c
+}
+
+func (c *RootCommand) VersionInfo() VersionInfo {
+	info := VersionInfo{
+		Name:      c.Label(),
+		Version:   c.version,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	if info.Version == "" {
+		info.Version = build.Main.Version
+	}
+	info.GoVersion = build.GoVersion
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Commit = setting.Value
+		case "vcs.time":
+			info.BuildDate = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	for _, dep := range build.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		info.Dependencies = append(info.Dependencies, VersionDependency{Path: dep.Path, Version: dep.Version})
+	}
+	return info
+}
+
+func (c *RootCommand) versionFlags() []Flag {
+	if !c.versionEnabled {
+		return nil
+	}
+	return []Flag{NewBoolFlag("version", "V", "Print version information", false)}
+}
+
+func isVersionFlag(arg string) bool {
+	return arg == "--version" || arg == "-V"
+}
+
+func (c *RootCommand) printVersion(ctx *Context) error {
+	tmpl := c.versionTemplate
+	if tmpl == nil {
+		tmpl = defaultVersion
+	}
+	if err := tmpl.Execute(ctx.Stdout(), c.VersionInfo()); err != nil {
+		return fmt.Errorf("error rendering version: %v", err)
+	}
+	return nil
+}
+
+func (c *RootCommand) printVersionJSON(ctx *Context) error {
+	data, err := json.MarshalIndent(c.VersionInfo(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(ctx.Stdout(), string(data))
+	return err
+}
+
+const defaultVersionTemplate = `{{ .Name }} version {{ .Version }}
+{{- if .Commit }}
+commit: {{ .Commit }}{{ if .Modified }} (modified){{ end }}
+{{- end }}
+{{- if .BuildDate }}
+built: {{ .BuildDate }}
+{{- end }}
+go: {{ .GoVersion }} {{ .Platform }}
+{{- if .Dependencies }}
+dependencies:
+{{- range .Dependencies }}
+  {{ .Path }} {{ .Version }}
+{{- end }}
+{{- end }}
+`
+
+var defaultVersion = template.Must(newHelpTemplate("version", defaultVersionTemplate))
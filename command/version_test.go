@@ -0,0 +1,27 @@
+package command_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+)
+
+func TestVersionCalledTwice(t *testing.T) {
+	var out strings.Builder
+	root := command.NewRootCommand("app", "Test application").
+		Version("1.0.0").
+		Version("1.1.0").
+		VersionTemplate("{{ .Name }} {{ .Version }}\n").
+		Stdout(&out)
+
+	for _, args := range [][]string{{"version"}, {"--version"}} {
+		out.Reset()
+		if err := root.Run(args); err != nil {
+			t.Fatalf("Run(%q) returned error: %v", args, err)
+		}
+		if got, want := out.String(), "app 1.1.0\n"; got != want {
+			t.Errorf("Run(%q) printed %q, want %q", args, got, want)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package doc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/azuyamat/gear/command"
+)
+
+type ManHeader struct {
+	Section string
+	Date    time.Time
+	Source  string
+	Manual  string
+}
+
+func (h ManHeader) withDefaults() ManHeader {
+	if h.Section == "" {
+		h.Section = "1"
+	}
+	if h.Date.IsZero() {
+		h.Date = buildDate()
+	}
+	return h
+}
+
+func buildDate() time.Time {
+	if epoch, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(epoch, 0).UTC()
+	}
+	return time.Now()
+}
+
+func ManPageName(info *command.CommandInfo) string {
+	return strings.Join(info.Path, "-")
+}
+
+func GenManTree(root *command.RootCommand, header ManHeader, dir string) error {
+	header = header.withDefaults()
+	return root.Info().Walk(func(info *command.CommandInfo) error {
+		path := filepath.Join(dir, ManPageName(info)+"."+header.Section)
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := GenMan(info, header, file); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+}
+
+func GenMan(info *command.CommandInfo, header ManHeader, w io.Writer) error {
+	header = header.withDefaults()
+	var b strings.Builder
+
+	fmt.Fprintf(&b, ".TH \"%s\" \"%s\" \"%s\" \"%s\" \"%s\"\n",
+		strings.ToUpper(ManPageName(info)),
+		header.Section,
+		header.Date.Format("Jan 2006"),
+		roffEscape(header.Source),
+		roffEscape(header.Manual))
+	b.WriteString(".nh\n.ad l\n")
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", ManPageName(info), roffEscape(info.Description))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	b.WriteString(manSynopsis(info) + "\n")
+
+	b.WriteString(".SH DESCRIPTION\n")
+	b.WriteString(roffText(info.Description) + "\n")
+
+	if len(info.Args) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+		for _, arg := range info.Args {
+			fmt.Fprintf(&b, ".TP\n\\fI%s\\fP (%s)%s\n%s\n",
+				roffEscape(arg.Label()),
+				arg.Expected(),
+				argQualifier(arg),
+				roffText(arg.Description()))
+		}
+	}
+
+	if len(info.Flags) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+		writeManFlags(&b, info.Flags)
+	}
+
+	if len(info.InheritedFlags) > 0 {
+		b.WriteString(".SH OPTIONS INHERITED FROM PARENT COMMANDS\n")
+		writeManFlags(&b, info.InheritedFlags)
+	}
+
+	if len(info.Children) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+		for _, child := range info.Children {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fP\n%s\n", roffEscape(child.Label), roffText(child.Description))
+		}
+	}
+
+	if len(info.Examples) > 0 {
+		b.WriteString(".SH EXAMPLES\n")
+		for _, example := range info.Examples {
+			fmt.Fprintf(&b, ".PP\n%s\n.PP\n.RS\n.nf\n%s\n.fi\n.RE\n", roffText(example.Title), roffText(example.CommandLine))
+		}
+	}
+
+	if seeAlso := manSeeAlso(info, header.Section); len(seeAlso) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		b.WriteString(strings.Join(seeAlso, ", ") + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func manSynopsis(info *command.CommandInfo) string {
+	parts := []string{fmt.Sprintf("\\fB%s\\fP", roffEscape(info.CommandPath()))}
+	if len(info.Flags)+len(info.InheritedFlags) > 0 {
+		parts = append(parts, "[\\fIflags\\fP]")
+	}
+	if !info.Runnable {
+		if len(info.Children) > 0 {
+			parts = append(parts, "\\fIcommand\\fP")
+		}
+		return strings.Join(parts, " ")
+	}
+
+	for _, arg := range info.Args {
+		label := fmt.Sprintf("\\fI%s\\fP", roffEscape(arg.Label()))
+		if arg.IsVariadic() {
+			label += "..."
+		}
+		if arg.IsOptional() {
+			label = "[" + label + "]"
+		}
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, " ")
+}
+
+func writeManFlags(b *strings.Builder, flags []command.Flag) {
+	for _, f := range flags {
+		names := fmt.Sprintf("\\fB\\-\\-%s\\fP", roffEscape(f.Name()))
+		if f.Shorthand() != "" {
+			names = fmt.Sprintf("\\fB\\-%s\\fP, %s", roffEscape(f.Shorthand()), names)
+		}
+		if f.Expected() != command.ValueTypeBool {
+			names += fmt.Sprintf("=\\fI%s\\fP", f.Expected())
+		}
+
+		description := f.Description()
+		if checks := constraints(f.Choices(), f.Constraints()); len(checks) > 0 {
+			description += fmt.Sprintf(" (%s)", strings.Join(checks, "; "))
+		}
+		if def := f.DefaultValue(); def != nil && fmt.Sprint(def) != "" {
+			description += fmt.Sprintf(" (default: %v)", def)
+		}
+		fmt.Fprintf(b, ".TP\n%s\n%s\n", names, roffText(description))
+	}
+}
+
+func argQualifier(arg command.Arg) string {
+	qualifiers := []string{}
+	if arg.IsOptional() {
+		qualifiers = append(qualifiers, "optional")
+	}
+	if arg.IsVariadic() {
+		qualifiers = append(qualifiers, "variadic")
+	}
+	qualifiers = append(qualifiers, constraints(arg.Choices(), arg.Constraints())...)
+	if len(qualifiers) == 0 {
+		return ""
+	}
+	return " \\- " + roffEscape(strings.Join(qualifiers, "; "))
+}
+
+func manSeeAlso(info *command.CommandInfo, section string) []string {
+	refs := []string{}
+	if info.Parent != nil {
+		refs = append(refs, fmt.Sprintf("\\fB%s\\fP(%s)", ManPageName(info.Parent), section))
+	}
+	for _, child := range info.Children {
+		refs = append(refs, fmt.Sprintf("\\fB%s\\fP(%s)", ManPageName(child), section))
+	}
+	return refs
+}
+
+func roffEscape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
+
+func roffText(s string) string {
+	lines := strings.Split(roffEscape(s), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,357 @@
+package doc
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/azuyamat/gear/command"
+)
+
+type Format int
+
+const (
+	FormatMarkdown Format = iota
+	FormatHTML
+)
+
+func (f Format) Extension() string {
+	if f == FormatHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+type PageOptions struct {
+	Format      Format
+	Template    string
+	Funcs       template.FuncMap
+	FrontMatter func(info *command.CommandInfo) string
+	LinkHandler func(info *command.CommandInfo) string
+}
+
+type Page struct {
+	Command        *command.CommandInfo
+	Title          string
+	Description    string
+	FrontMatter    string
+	Synopsis       string
+	Parent         *Link
+	Children       []Link
+	Args           []ArgRow
+	Flags          []FlagRow
+	InheritedFlags []FlagRow
+	Examples       []command.Example
+}
+
+type Link struct {
+	Label       string
+	Description string
+	URL         string
+}
+
+type ArgRow struct {
+	Name        string
+	Type        string
+	Required    bool
+	Variadic    bool
+	Choices     []string
+	Constraints []string
+	Description string
+}
+
+type FlagRow struct {
+	Name        string
+	Shorthand   string
+	Type        string
+	Default     string
+	Choices     []string
+	Constraints []string
+	Description string
+}
+
+func GenPageTree(root *command.RootCommand, opts PageOptions, dir string) error {
+	return root.Info().Walk(func(info *command.CommandInfo) error {
+		path := filepath.Join(dir, ManPageName(info)+opts.Format.Extension())
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := GenPage(info, opts, file); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+}
+
+func GenPage(info *command.CommandInfo, opts PageOptions, w io.Writer) error {
+	page := NewPage(info, opts)
+
+	text := opts.Template
+	if text == "" {
+		text = defaultMarkdownTemplate
+		if opts.Format == FormatHTML {
+			text = defaultHTMLTemplate
+		}
+	}
+
+	var buf bytes.Buffer
+	if opts.Format == FormatHTML {
+		tmpl, err := htmltemplate.New("page").Funcs(htmltemplate.FuncMap(pageFuncs(opts))).Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid page template: %v", err)
+		}
+		if err := tmpl.Execute(&buf, page); err != nil {
+			return err
+		}
+	} else {
+		tmpl, err := template.New("page").Funcs(pageFuncs(opts)).Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid page template: %v", err)
+		}
+		if err := tmpl.Execute(&buf, page); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func NewPage(info *command.CommandInfo, opts PageOptions) Page {
+	link := opts.LinkHandler
+	if link == nil {
+		link = func(info *command.CommandInfo) string {
+			return ManPageName(info) + opts.Format.Extension()
+		}
+	}
+
+	page := Page{
+		Command:     info,
+		Title:       info.CommandPath(),
+		Description: info.Description,
+		Synopsis:    info.Synopsis(),
+		Examples:    info.Examples,
+	}
+
+	if opts.FrontMatter != nil {
+		page.FrontMatter = opts.FrontMatter(info)
+	} else {
+		page.FrontMatter = fmt.Sprintf("---\ntitle: %q\ndescription: %q\n---\n", info.CommandPath(), info.Description)
+	}
+
+	if info.Parent != nil {
+		page.Parent = &Link{Label: info.Parent.CommandPath(), Description: info.Parent.Description, URL: link(info.Parent)}
+	}
+	for _, child := range info.Children {
+		page.Children = append(page.Children, Link{Label: child.CommandPath(), Description: child.Description, URL: link(child)})
+	}
+
+	for _, arg := range info.Args {
+		page.Args = append(page.Args, ArgRow{
+			Name:        arg.Label(),
+			Type:        string(arg.Expected()),
+			Required:    !arg.IsOptional(),
+			Variadic:    arg.IsVariadic(),
+			Choices:     arg.Choices(),
+			Constraints: constraints(arg.Choices(), arg.Constraints()),
+			Description: arg.Description(),
+		})
+	}
+	page.Flags = flagRows(info.Flags)
+	page.InheritedFlags = flagRows(info.InheritedFlags)
+	return page
+}
+
+func flagRows(flags []command.Flag) []FlagRow {
+	rows := []FlagRow{}
+	for _, f := range flags {
+		def := ""
+		if f.DefaultValue() != nil {
+			def = fmt.Sprint(f.DefaultValue())
+		}
+		rows = append(rows, FlagRow{
+			Name:        f.Name(),
+			Shorthand:   f.Shorthand(),
+			Type:        string(f.Expected()),
+			Default:     def,
+			Choices:     f.Choices(),
+			Constraints: constraints(f.Choices(), f.Constraints()),
+			Description: f.Description(),
+		})
+	}
+	return rows
+}
+
+func constraints(choices []string, checks []string) []string {
+	list := []string{}
+	if len(choices) > 0 {
+		list = append(list, "one of: "+strings.Join(choices, ", "))
+	}
+	return append(list, checks...)
+}
+
+func pageFuncs(opts PageOptions) template.FuncMap {
+	funcs := template.FuncMap{
+		"cell": func(s string) string {
+			return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+		},
+		"join": strings.Join,
+	}
+	for name, fn := range opts.Funcs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+const defaultMarkdownTemplate = `{{ .FrontMatter }}
+# {{ .Title }}
+
+{{ .Description }}
+
+## Synopsis
+
+` + "```" + `
+{{ .Synopsis }}
+` + "```" + `
+{{- if .Args }}
+
+## Arguments
+
+| Name | Type | Required | Constraints | Description |
+|------|------|----------|-------------|-------------|
+{{- range .Args }}
+| ` + "`{{ .Name }}`" + `{{ if .Variadic }}...{{ end }} | {{ .Type }} | {{ if .Required }}yes{{ else }}no{{ end }} | {{ cell (join .Constraints "; ") }} | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- if .Flags }}
+
+## Flags
+
+| Flag | Type | Default | Constraints | Description |
+|------|------|---------|-------------|-------------|
+{{- range .Flags }}
+| ` + "`--{{ .Name }}`" + `{{ if .Shorthand }}, ` + "`-{{ .Shorthand }}`" + `{{ end }} | {{ .Type }} | {{ if .Default }}` + "`{{ cell .Default }}`" + `{{ end }} | {{ cell (join .Constraints "; ") }} | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- if .InheritedFlags }}
+
+## Inherited Flags
+
+| Flag | Type | Default | Constraints | Description |
+|------|------|---------|-------------|-------------|
+{{- range .InheritedFlags }}
+| ` + "`--{{ .Name }}`" + `{{ if .Shorthand }}, ` + "`-{{ .Shorthand }}`" + `{{ end }} | {{ .Type }} | {{ if .Default }}` + "`{{ cell .Default }}`" + `{{ end }} | {{ cell (join .Constraints "; ") }} | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- if .Examples }}
+
+## Examples
+{{- range .Examples }}
+
+{{ .Title }}
+
+` + "```sh" + `
+{{ .CommandLine }}
+` + "```" + `
+{{- end }}
+{{- end }}
+{{- if .Children }}
+
+## Commands
+
+| Command | Description |
+|---------|-------------|
+{{- range .Children }}
+| [{{ .Label }}]({{ .URL }}) | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- if .Parent }}
+
+## See Also
+
+- [{{ .Parent.Label }}]({{ .Parent.URL }}) - {{ .Parent.Description }}
+{{- end }}
+`
+
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<p>{{ .Description }}</p>
+
+<h2>Synopsis</h2>
+<pre><code>{{ .Synopsis }}</code></pre>
+{{- if .Args }}
+
+<h2>Arguments</h2>
+<table>
+<thead><tr><th>Name</th><th>Type</th><th>Required</th><th>Constraints</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Args }}
+<tr><td><code>{{ .Name }}</code>{{ if .Variadic }}...{{ end }}</td><td>{{ .Type }}</td><td>{{ if .Required }}yes{{ else }}no{{ end }}</td><td>{{ join .Constraints "; " }}</td><td>{{ .Description }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .Flags }}
+
+<h2>Flags</h2>
+<table>
+<thead><tr><th>Flag</th><th>Type</th><th>Default</th><th>Constraints</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Flags }}
+<tr><td><code>--{{ .Name }}</code>{{ if .Shorthand }}, <code>-{{ .Shorthand }}</code>{{ end }}</td><td>{{ .Type }}</td><td>{{ if .Default }}<code>{{ .Default }}</code>{{ end }}</td><td>{{ join .Constraints "; " }}</td><td>{{ .Description }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .InheritedFlags }}
+
+<h2>Inherited Flags</h2>
+<table>
+<thead><tr><th>Flag</th><th>Type</th><th>Default</th><th>Constraints</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .InheritedFlags }}
+<tr><td><code>--{{ .Name }}</code>{{ if .Shorthand }}, <code>-{{ .Shorthand }}</code>{{ end }}</td><td>{{ .Type }}</td><td>{{ if .Default }}<code>{{ .Default }}</code>{{ end }}</td><td>{{ join .Constraints "; " }}</td><td>{{ .Description }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .Examples }}
+
+<h2>Examples</h2>
+{{- range .Examples }}
+<p>{{ .Title }}</p>
+<pre><code>{{ .CommandLine }}</code></pre>
+{{- end }}
+{{- end }}
+{{- if .Children }}
+
+<h2>Commands</h2>
+<ul>
+{{- range .Children }}
+<li><a href="{{ .URL }}">{{ .Label }}</a> - {{ .Description }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- if .Parent }}
+
+<h2>See Also</h2>
+<ul>
+<li><a href="{{ .Parent.URL }}">{{ .Parent.Label }}</a> - {{ .Parent.Description }}</li>
+</ul>
+{{- end }}
+</body>
+</html>
+`
@@ -0,0 +1,72 @@
+package doc_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/command"
+	"github.com/azuyamat/gear/doc"
+)
+
+func lintInfo(t *testing.T) *command.CommandInfo {
+	t.Helper()
+	lint := command.NewExecutableCommand("lint", "Lint files").
+		Args(command.NewPathArg("files", "Files to lint").MustBeFile().Readable().Extensions(".yaml").AsVariadic()).
+		Flags(
+			command.NewPathFlag("out", "o", "Report directory", "reports").MustBeDir().Writable(),
+			command.NewEnumFlag("format", "f", "Report format", "text", "text", "json"),
+		)
+	root := command.NewRootCommand("app", "Test application").AddChild(lint)
+	for _, child := range root.Info().Children {
+		if child.Label == "lint" {
+			return child
+		}
+	}
+	t.Fatal("lint command not found")
+	return nil
+}
+
+func TestPageConstraints(t *testing.T) {
+	page := doc.NewPage(lintInfo(t), doc.PageOptions{})
+
+	if got, want := page.Args[0].Constraints, []string{"existing file", "readable", "extensions: .yaml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("files constraints = %q, want %q", got, want)
+	}
+	flags := map[string][]string{}
+	for _, f := range page.Flags {
+		flags[f.Name] = f.Constraints
+	}
+	if got, want := flags["out"], []string{"existing directory", "writable"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("--out constraints = %q, want %q", got, want)
+	}
+	if got, want := flags["format"], []string{"one of: text, json"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("--format constraints = %q, want %q", got, want)
+	}
+}
+
+func TestGenPageConstraintsColumn(t *testing.T) {
+	for _, format := range []doc.Format{doc.FormatMarkdown, doc.FormatHTML} {
+		var b strings.Builder
+		if err := doc.GenPage(lintInfo(t), doc.PageOptions{Format: format}, &b); err != nil {
+			t.Fatalf("GenPage(%v) returned error: %v", format, err)
+		}
+		for _, want := range []string{"Constraints", "existing file; readable; extensions: .yaml", "existing directory; writable", "one of: text, json"} {
+			if !strings.Contains(b.String(), want) {
+				t.Errorf("GenPage(%v) output missing %q:\n%s", format, want, b.String())
+			}
+		}
+	}
+}
+
+func TestGenManConstraints(t *testing.T) {
+	var b strings.Builder
+	if err := doc.GenMan(lintInfo(t), doc.ManHeader{}, &b); err != nil {
+		t.Fatalf("GenMan returned error: %v", err)
+	}
+	for _, want := range []string{"variadic; existing file; readable; extensions: .yaml", "Report directory (existing directory; writable)", "Report format (one of: text, json)"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("GenMan output missing %q:\n%s", want, b.String())
+		}
+	}
+}
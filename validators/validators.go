@@ -0,0 +1,172 @@
+package validators
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+func Range[T cmp.Ordered](min, max T) func(T) error {
+	return func(value T) error {
+		if value < min || value > max {
+			return fmt.Errorf("must be between %v and %v, got %v", min, max, value)
+		}
+		return nil
+	}
+}
+
+func Min[T cmp.Ordered](min T) func(T) error {
+	return func(value T) error {
+		if value < min {
+			return fmt.Errorf("must be at least %v, got %v", min, value)
+		}
+		return nil
+	}
+}
+
+func Max[T cmp.Ordered](max T) func(T) error {
+	return func(value T) error {
+		if value > max {
+			return fmt.Errorf("must be at most %v, got %v", max, value)
+		}
+		return nil
+	}
+}
+
+func NonEmpty[T ~string]() func(T) error {
+	return func(value T) error {
+		if strings.TrimSpace(string(value)) == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	}
+}
+
+func Length[T ~string](min, max int) func(T) error {
+	return func(value T) error {
+		length := utf8.RuneCountInString(string(value))
+		if length < min || length > max {
+			return fmt.Errorf("must be between %d and %d characters long, got %d", min, max, length)
+		}
+		return nil
+	}
+}
+
+func Regex[T ~string](pattern string) func(T) error {
+	re := regexp.MustCompile(pattern)
+	return func(value T) error {
+		if !re.MatchString(string(value)) {
+			return fmt.Errorf("must match pattern %s", pattern)
+		}
+		return nil
+	}
+}
+
+func OneOf[T comparable](allowed ...T) func(T) error {
+	return func(value T) error {
+		for _, a := range allowed {
+			if value == a {
+				return nil
+			}
+		}
+		choices := make([]string, len(allowed))
+		for i, a := range allowed {
+			choices[i] = fmt.Sprint(a)
+		}
+		return fmt.Errorf("must be one of %s, got %v", strings.Join(choices, ", "), value)
+	}
+}
+
+func Unique[T comparable]() func([]T) error {
+	return func(values []T) error {
+		seen := make(map[T]int, len(values))
+		for i, value := range values {
+			if first, ok := seen[value]; ok {
+				return fmt.Errorf("must not contain duplicates, %v appears at positions %d and %d", value, first, i)
+			}
+			seen[value] = i
+		}
+		return nil
+	}
+}
+
+func Email[T ~string]() func(T) error {
+	return func(value T) error {
+		address, err := mail.ParseAddress(string(value))
+		if err != nil || address.Address != string(value) {
+			return fmt.Errorf("must be a valid email address, got %q", value)
+		}
+		return nil
+	}
+}
+
+var hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+func Hostname[T ~string]() func(T) error {
+	return func(value T) error {
+		host := strings.TrimSuffix(string(value), ".")
+		if host == "" || len(host) > 253 {
+			return fmt.Errorf("must be a valid hostname, got %q", value)
+		}
+		for _, label := range strings.Split(host, ".") {
+			if !hostnameLabel.MatchString(label) {
+				return fmt.Errorf("must be a valid hostname, got %q", value)
+			}
+		}
+		return nil
+	}
+}
+
+var semverPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+func Semver[T ~string]() func(T) error {
+	return func(value T) error {
+		if !semverPattern.MatchString(string(value)) {
+			return fmt.Errorf("must be a semantic version (e.g. 1.2.3), got %q", value)
+		}
+		return nil
+	}
+}
+
+func All[T any](validators ...func(T) error) func(T) error {
+	return func(value T) error {
+		for _, v := range validators {
+			if err := v(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func Any[T any](validators ...func(T) error) func(T) error {
+	return func(value T) error {
+		if len(validators) == 0 {
+			return nil
+		}
+		messages := make([]string, 0, len(validators))
+		for _, v := range validators {
+			err := v(value)
+			if err == nil {
+				return nil
+			}
+			messages = append(messages, err.Error())
+		}
+		return errors.New(strings.Join(messages, " or "))
+	}
+}
+
+func Not[T any](validator func(T) error, message string) func(T) error {
+	return func(value T) error {
+		if validator(value) == nil {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package validators_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/azuyamat/gear/validators"
+)
+
+type name string
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Range inside", validators.Range(1, 10)(5), ""},
+		{"Range at bounds", validators.Range(1.5, 2.5)(2.5), ""},
+		{"Range below", validators.Range(1, 10)(0), "must be between 1 and 10, got 0"},
+		{"Range strings", validators.Range("b", "d")("e"), "must be between b and d, got e"},
+		{"Min", validators.Min(3)(3), ""},
+		{"Min below", validators.Min(3)(2), "must be at least 3, got 2"},
+		{"Max", validators.Max(3)(3), ""},
+		{"Max above", validators.Max(3)(4), "must be at most 3, got 4"},
+		{"NonEmpty", validators.NonEmpty[string]()("x"), ""},
+		{"NonEmpty blank", validators.NonEmpty[string]()(" \t"), "must not be empty"},
+		{"NonEmpty named type", validators.NonEmpty[name]()(""), "must not be empty"},
+		{"Length", validators.Length[string](2, 3)("héé"), ""},
+		{"Length short", validators.Length[string](2, 3)("é"), "must be between 2 and 3 characters long, got 1"},
+		{"Length long", validators.Length[name](2, 3)("abcd"), "got 4"},
+		{"Regex", validators.Regex[string](`^[a-z]+$`)("abc"), ""},
+		{"Regex mismatch", validators.Regex[string](`^[a-z]+$`)("ab1"), "must match pattern ^[a-z]+$"},
+		{"OneOf", validators.OneOf("json", "text")("text"), ""},
+		{"OneOf miss", validators.OneOf("json", "text")("yaml"), "must be one of json, text, got yaml"},
+		{"OneOf ints", validators.OneOf(1, 2)(3), "must be one of 1, 2, got 3"},
+		{"Unique", validators.Unique[string]()([]string{"a", "b"}), ""},
+		{"Unique empty", validators.Unique[int]()(nil), ""},
+		{"Unique duplicate", validators.Unique[string]()([]string{"a", "b", "a"}), "a appears at positions 0 and 2"},
+		{"Email", validators.Email[string]()("ops@example.com"), ""},
+		{"Email with name", validators.Email[string]()("Ops <ops@example.com>"), "must be a valid email address"},
+		{"Email invalid", validators.Email[string]()("ops@"), `got "ops@"`},
+		{"Hostname", validators.Hostname[string]()("api-1.example.com"), ""},
+		{"Hostname trailing dot", validators.Hostname[string]()("example.com."), ""},
+		{"Hostname empty", validators.Hostname[string]()(""), "must be a valid hostname"},
+		{"Hostname leading hyphen", validators.Hostname[string]()("-api.example.com"), "must be a valid hostname"},
+		{"Hostname long label", validators.Hostname[string]()(strings.Repeat("a", 64) + ".com"), "must be a valid hostname"},
+		{"Hostname too long", validators.Hostname[string]()(strings.Repeat("a.", 127) + "ab"), "must be a valid hostname"},
+		{"Semver", validators.Semver[string]()("1.2.3"), ""},
+		{"Semver prefixed pre-release", validators.Semver[string]()("v1.2.3-rc.1+build.5"), ""},
+		{"Semver short", validators.Semver[string]()("1.2"), "must be a semantic version"},
+		{"Semver leading zero", validators.Semver[string]()("01.2.3"), "must be a semantic version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, tt.err, tt.want)
+		})
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	small := validators.Max(10)
+	even := func(n int) error {
+		if n%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"All passes", validators.All(small, even)(4), ""},
+		{"All stops at first failure", validators.All(small, even)(11), "must be at most 10, got 11"},
+		{"All second failure", validators.All(small, even)(3), "must be even"},
+		{"All empty", validators.All[int]()(3), ""},
+		{"Any first passes", validators.Any(small, even)(3), ""},
+		{"Any second passes", validators.Any(small, even)(12), ""},
+		{"Any fails", validators.Any(small, even)(13), "must be at most 10, got 13 or must be even"},
+		{"Any empty", validators.Any[int]()(3), ""},
+		{"Not passes", validators.Not(even, "must be odd")(3), ""},
+		{"Not fails", validators.Not(even, "must be odd")(4), "must be odd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, tt.err, tt.want)
+		})
+	}
+}
+
+func checkError(t *testing.T, err error, want string) {
+	t.Helper()
+	if want == "" {
+		if err != nil {
+			t.Errorf("got %v, want nil", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("got %v, want an error containing %q", err, want)
+	}
+}